@@ -0,0 +1,105 @@
+/* -----------------------------------------------------------------
+ *				   P u b l i c   D o m a i n / F O S
+ *				Copyright (C)2025 Dídimo Grimaldo T.
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * Readers keeping their own position in a RingQueue, much like
+ * tail -f does on a file: the writer never waits for them and a
+ * reader that falls too far behind learns how much it missed.
+ *-----------------------------------------------------------------*/
+package roundrobin
+
+import (
+	"sync"
+)
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		T y p e s
+ *-----------------------------------------------------------------*/
+
+// An independent reader of the elements pushed onto a queue, which
+// reads them without popping them. Every element pushed gets the
+// next sequence number, and the cursor remembers the one it reads
+// next. The elements popped, taken back or overwritten before it read
+// them are lost for it, which it reports with ErrOverrun.
+type Cursor[T any] struct {
+	rq      *RingQueue[T]
+	mutex   sync.Locker // the queue's own, if thread-safe
+	next    uint64      // sequence number of the next element to read
+	skipped uint64      // elements lost to overruns so far
+}
+
+/* ----------------------------------------------------------------
+ *				C o n s t r u c t o r s
+ *-----------------------------------------------------------------*/
+
+// a cursor on the oldest element of rq, locking mutex (unless nil)
+// whenever it looks at the queue.
+func newCursor[T any](rq *RingQueue[T], mutex sync.Locker) *Cursor[T] {
+	return &Cursor[T]{
+		rq:    rq,
+		mutex: mutex,
+		next:  rq.OldestSeq(),
+	}
+}
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// The next element, or ErrEmptyQueue once it caught up with the
+// writer. If elements were lost since the previous call it returns
+// ErrOverrun instead, just once, and resumes with the oldest element
+// left. Skipped() tells how many were lost.
+func (c *Cursor[T]) Next() (elem T, err error) {
+	if c.mutex != nil {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+	}
+
+	r := c.rq
+	if r.closed {
+		return elem, ErrClosed
+	}
+
+	// the numbers missing up to the first element left are lost
+	lost := r.seq + 1 - c.next
+	i := r.seqIndex(c.next)
+	if i < r.Size() {
+		lost = r.seqAt(i) - c.next
+	}
+	if lost > 0 {
+		c.skipped += lost
+		c.next += lost
+		return elem, ErrOverrun
+	}
+
+	if i == r.Size() {
+		return elem, ErrEmptyQueue
+	}
+
+	elem = r.data[(r.start+i)%len(r.data)]
+	c.next++
+
+	return elem, nil
+}
+
+// How many elements the writer is ahead of the cursor, including
+// those that are already lost.
+func (c *Cursor[T]) Lag() int {
+	if c.mutex != nil {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+	}
+
+	return int(c.rq.seq + 1 - c.next)
+}
+
+// The elements lost to overruns since the cursor was created.
+func (c *Cursor[T]) Skipped() uint64 {
+	if c.mutex != nil {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+	}
+
+	return c.skipped
+}
@@ -0,0 +1,133 @@
+/* -----------------------------------------------------------------
+ *				   P u b l i c   D o m a i n / F O S
+ *				Copyright (C)2025 Dídimo Grimaldo T.
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * Tests for the independent reader cursors
+ *-----------------------------------------------------------------*/
+package roundrobin
+
+import (
+	"sync"
+	"testing"
+)
+
+/* ----------------------------------------------------------------
+ *						T e s t s
+ *-----------------------------------------------------------------*/
+
+func Test_Cursor(t *testing.T) {
+	obj := NewRingQueue[int](4)
+	obj.SetWhenFull(WhenFullOverwrite)
+
+	early := obj.NewCursor()
+	obj.PushN([]int{0, 1, 2})
+	for want := range 2 {
+		if v, err := early.Next(); v != want || err != nil {
+			t.Fatalf("Next exp %d, nil got %d, %v", want, v, err)
+		}
+	}
+	if lag := early.Lag(); lag != 1 {
+		t.Errorf("Lag exp 1 got %d", lag)
+	}
+
+	late := obj.NewCursor() // on 0, the oldest
+	obj.Push(3)
+	obj.Push(4) // overwrites 0
+	obj.Push(5) // overwrites 1
+	if obj.Size() != 4 {
+		t.Fatalf("cursors do not pop, size exp 4 got %d", obj.Size())
+	}
+
+	if _, err := late.Next(); err != ErrOverrun {
+		t.Fatalf("Next after an overwrite exp ErrOverrun got %v", err)
+	}
+	if late.Skipped() != 2 {
+		t.Errorf("Skipped exp 2 got %d", late.Skipped())
+	}
+	for _, c := range []*Cursor[int]{early, late} {
+		for want := 2; want <= 5; want++ {
+			if v, err := c.Next(); v != want || err != nil {
+				t.Fatalf("Next exp %d, nil got %d, %v", want, v, err)
+			}
+		}
+		if _, err := c.Next(); err != ErrEmptyQueue || c.Lag() != 0 {
+			t.Fatalf("caught up Next exp ErrEmptyQueue & no lag got %v & %d", err, c.Lag())
+		}
+	}
+	if early.Skipped() != 0 {
+		t.Errorf("Skipped exp 0 got %d", early.Skipped())
+	}
+
+	obj.PopBack() // takes 5 back from the cursors
+	if _, err := early.Next(); err != ErrEmptyQueue || early.Lag() != 0 {
+		t.Fatalf("Next after PopBack exp ErrEmptyQueue & no lag got %v & %d", err, early.Lag())
+	}
+	obj.Push(6)
+	if v, err := early.Next(); v != 6 || err != nil {
+		t.Fatalf("Next exp 6, nil got %d, %v", v, err)
+	}
+
+	// an unread element taken back leaves a gap before the next push
+	obj.Push(7)
+	obj.PopBack()
+	obj.Push(8)
+	if _, err := early.Next(); err != ErrOverrun || early.Skipped() != 1 {
+		t.Fatalf("Next after PopBack of an unread exp ErrOverrun & 1 skipped got %v & %d", err, early.Skipped())
+	}
+	if v, err := early.Next(); v != 8 || err != nil {
+		t.Fatalf("Next exp 8, nil got %d, %v", v, err)
+	}
+
+	obj.Close()
+	if _, err := early.Next(); err != ErrClosed {
+		t.Fatalf("Next after Close exp ErrClosed got %v", err)
+	}
+}
+
+// every element is either read or counted as skipped by each reader
+func Test_CursorConcurrent(t *testing.T) {
+	const total = 10_000
+	obj := NewSafeRingQueue[int](16, WhenFullOverwrite, WhenEmptyError, nil)
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	for range 4 {
+		c := obj.NewCursor()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			read, last := 0, -1
+			for {
+				v, err := c.Next()
+				switch err {
+				case nil:
+					if v <= last {
+						t.Errorf("out of order: %d after %d", v, last)
+						return
+					}
+					read, last = read+1, v
+					continue
+				case ErrOverrun:
+					continue
+				}
+
+				select {
+				case <-done:
+					if c.Lag() == 0 {
+						if n := read + int(c.Skipped()); n != total {
+							t.Errorf("read + skipped exp %d got %d", total, n)
+						}
+						return
+					}
+				default:
+				}
+			}
+		}()
+	}
+
+	for i := range total {
+		obj.Push(i)
+	}
+	close(done)
+	wg.Wait()
+}
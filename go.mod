@@ -1,5 +1,5 @@
 module github.com/lordofscripts/go-roundrobin
 
-go 1.21
+go 1.24
 
 require github.com/pion/transport/v3 v3.0.7
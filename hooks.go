@@ -0,0 +1,52 @@
+/* -----------------------------------------------------------------
+ *				   P u b l i c   D o m a i n / F O S
+ *				Copyright (C)2025 Dídimo Grimaldo T.
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * Hooks tracing the elements as they flow through a queue, for
+ * auditing without wrapping every call site.
+ *-----------------------------------------------------------------*/
+package roundrobin
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		T y p e s
+ *-----------------------------------------------------------------*/
+
+// The functions a queue calls as elements come and go, installed
+// all at once with SetHooks(). Any of them may be left nil. They run
+// in the Go routine using the queue, right after the change, except
+// OnOverwrite which gets the element before it is overwritten.
+type Hooks[T any] struct {
+	OnPush      func(elem T)        // an element was queued
+	OnPop       func(elem T)        // an element was dequeued
+	OnOverwrite func(evicted T)     // WhenFullOverwrite discarded an element
+	OnReset     func(discarded int) // Reset() discarded that many elements
+	OnClose     OnCloseCallback[T]  // replaces the SetOnClose() callback
+}
+
+/* ----------------------------------------------------------------
+ *				P r i v a t e	M e t h o d s
+ *-----------------------------------------------------------------*/
+
+func (h *Hooks[T]) push(elem T) {
+	if h.OnPush != nil {
+		h.OnPush(elem)
+	}
+}
+
+func (h *Hooks[T]) pop(elem T) {
+	if h.OnPop != nil {
+		h.OnPop(elem)
+	}
+}
+
+func (h *Hooks[T]) overwrite(evicted T) {
+	if h.OnOverwrite != nil {
+		h.OnOverwrite(evicted)
+	}
+}
+
+func (h *Hooks[T]) reset(discarded int) {
+	if h.OnReset != nil {
+		h.OnReset(discarded)
+	}
+}
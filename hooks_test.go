@@ -0,0 +1,111 @@
+/* -----------------------------------------------------------------
+ *				   P u b l i c   D o m a i n / F O S
+ *				Copyright (C)2025 Dídimo Grimaldo T.
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * Tests for the event hooks of the queues
+ *-----------------------------------------------------------------*/
+package roundrobin
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+/* ----------------------------------------------------------------
+ *						T e s t s
+ *-----------------------------------------------------------------*/
+
+// the same hooks on every IRingQueue implementation
+func Test_Hooks(t *testing.T) {
+	var events []string
+	hooks := Hooks[int]{
+		OnPush:      func(elem int) { events = append(events, fmt.Sprintf("push:%d", elem)) },
+		OnPop:       func(elem int) { events = append(events, fmt.Sprintf("pop:%d", elem)) },
+		OnOverwrite: func(evicted int) { events = append(events, fmt.Sprintf("overwrite:%d", evicted)) },
+		OnReset:     func(discarded int) { events = append(events, fmt.Sprintf("reset:%d", discarded)) },
+		OnClose:     func(elem int) { events = append(events, fmt.Sprintf("close:%d", elem)) },
+	}
+
+	tests := []struct {
+		name      string
+		queue     IRingQueue[int]
+		overwrite bool
+	}{
+		{"RingQueue", NewRingQueue[int](2).SetWhenFull(WhenFullOverwrite), true},
+		{"SafeRingQueue", NewSafeRingQueue[int](2, WhenFullOverwrite, WhenEmptyError, nil), true},
+		{"Synchronized", Synchronized[int](NewRingQueue[int](2)), false},
+		{"SPSCRingQueue", NewSPSCRingQueue[int](2), false},
+		{"MPMCRingQueue", NewMPMCRingQueue[int](2, WhenFullOverwrite, WhenEmptyError, nil), true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			events = nil
+			q := tc.queue.SetHooks(hooks)
+			q.Push(1)
+			q.Push(2)
+			q.Pop()
+			q.Push(3)
+			q.Push(4) // full
+			q.Reset()
+			q.Push(5)
+			q.Close()
+
+			var expected []string
+			if tc.overwrite {
+				expected = []string{"push:1", "push:2", "pop:1", "push:3", "overwrite:2", "push:4", "reset:2", "push:5", "close:5"}
+			} else {
+				expected = []string{"push:1", "push:2", "pop:1", "push:3", "reset:2", "push:5", "close:5"}
+			}
+			if !slices.Equal(events, expected) {
+				t.Errorf("events mismatch, expected:%v, found:%v", expected, events)
+			}
+		})
+	}
+}
+
+// OnClose flushes a full queue as well, where the end is the start
+func Test_HooksCloseFull(t *testing.T) {
+	var flushed []int
+	obj := NewRingQueue[int](3)
+	obj.SetHooks(Hooks[int]{OnClose: func(elem int) { flushed = append(flushed, elem) }})
+	obj.PushN([]int{1, 2, 3})
+	obj.Close()
+
+	if expected := []int{1, 2, 3}; !slices.Equal(flushed, expected) {
+		t.Errorf("OnClose mismatch, expected:%v, found:%v", expected, flushed)
+	}
+}
+
+func Test_HooksBatches(t *testing.T) {
+	var pushed, popped []rune
+	runes := NewRuneRingQueue(4)
+	runes.SetHooks(Hooks[rune]{
+		OnPush: func(elem rune) { pushed = append(pushed, elem) },
+		OnPop:  func(elem rune) { popped = append(popped, elem) },
+	})
+	runes.WriteString("abc")
+	runes.ReadRune()
+	runes.WriteString("def") // wraps, f rejected
+	runes.ReadString('e')
+
+	if string(pushed) != "abcde" || string(popped) != "abcde" {
+		t.Errorf("rune hooks mismatch, pushed:%q popped:%q", string(pushed), string(popped))
+	}
+
+	var written, read []byte
+	bytes := NewByteRingQueue(4)
+	bytes.SetHooks(Hooks[byte]{
+		OnPush: func(elem byte) { written = append(written, elem) },
+		OnPop:  func(elem byte) { read = append(read, elem) },
+	})
+	bytes.Write([]byte("xyz"))
+	bytes.Read(make([]byte, 2))
+	bytes.WriteByte('w')
+	bytes.Read(make([]byte, 4))
+
+	if string(written) != "xyzw" || string(read) != "xyzw" {
+		t.Errorf("byte hooks mismatch, written:%q read:%q", string(written), string(read))
+	}
+}
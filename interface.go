@@ -9,6 +9,7 @@
 package roundrobin
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"time"
@@ -21,6 +22,16 @@ import (
 const ( // what happens when Push() on a full circular buffer
 	WhenFullError WhenFull = iota
 	WhenFullOverwrite
+	WhenFullBlock
+	WhenFullGrow
+	WhenFullDropNewest
+	WhenFullCustom
+)
+
+const ( // what a FullPolicy chooses for WhenFullCustom
+	DecisionFail Decision = iota
+	DecisionDrop
+	DecisionOverwrite
 )
 
 const ( // what happens when Pop() on an empty circular buffer
@@ -29,10 +40,21 @@ const ( // what happens when Pop() on an empty circular buffer
 )
 
 var ( // module errors
-	ErrFullQueue   = fmt.Errorf("ring buffer is full")
-	ErrEmptyQueue  = fmt.Errorf("ring buffer is empty")
-	ErrClosed      = fmt.Errorf("ring buffer is closed")
-	ErrBadDeadline = fmt.Errorf("deadline only possible for WhenEmptyBlock")
+	ErrFullQueue       = fmt.Errorf("ring buffer is full")
+	ErrEmptyQueue      = fmt.Errorf("ring buffer is empty")
+	ErrClosed          = fmt.Errorf("ring buffer is closed")
+	ErrBadDeadline     = fmt.Errorf("deadline only possible for WhenEmptyBlock")
+	ErrBadPushDeadline = fmt.Errorf("push deadline only possible for WhenFullBlock")
+	ErrOutOfRange      = fmt.Errorf("ring buffer index out of range")
+	ErrBadCapacity     = fmt.Errorf("ring buffer capacity must be positive")
+	ErrBadGrowth       = fmt.Errorf("growth factor must be greater than one")
+	ErrBadOption       = fmt.Errorf("invalid option value")
+	ErrBadUnread       = fmt.Errorf("unread only possible right after a read")
+	ErrBadMark         = fmt.Errorf("mark unknown or already committed")
+	ErrOverrun         = fmt.Errorf("cursor overrun, elements were lost")
+	ErrBadWatermarks   = fmt.Errorf("watermarks must satisfy 0 <= low <= high")
+	ErrSelfDrain       = fmt.Errorf("ring buffer cannot be drained onto itself")
+	ErrDropped         = fmt.Errorf("element dropped by the destination")
 )
 
 /* ----------------------------------------------------------------
@@ -46,6 +68,7 @@ type IRingQueue[T any] interface {
 	SetPopDeadline(t time.Time) error
 	SetWhenFull(a WhenFull) IRingQueue[T]
 	SetOnClose(callback OnCloseCallback[T]) IRingQueue[T]
+	SetHooks(hooks Hooks[T]) IRingQueue[T]
 
 	Size() int
 	Cap() int
@@ -57,11 +80,76 @@ type IRingQueue[T any] interface {
 	Reset()
 }
 
+// A ring queue whose Push and Pop can be cancelled per call
+// via a context rather than via the queue-wide deadlines.
+type ContextQueue[T any] interface {
+	IRingQueue[T]
+
+	PushCtx(ctx context.Context, element T) (newLen int, err error)
+	PopCtx(ctx context.Context) (element T, newLen int, err error)
+}
+
+// A ring queue whose Push() can block on a full queue (WhenFullBlock)
+// until the push deadline, like Pop() does until the pop deadline.
+type PushDeadlineQueue[T any] interface {
+	IRingQueue[T]
+
+	SetPushDeadline(t time.Time) error
+}
+
+// A ring queue keeping runtime statistics, which may be read
+// while the queue is in use.
+type StatsQueue interface {
+	Stats() QueueStats
+	ResetStats()
+}
+
+// A ring queue reporting when its size reaches the high watermark
+// and, only after that, when it falls under the low watermark.
+type WatermarkQueue interface {
+	SetWatermarks(high, low int, onHigh, onLow WatermarkCallback) error
+}
+
+// A double-ended ring queue. When full, WhenFullOverwrite evicts
+// from the opposite end: PushFront() drops the newest element.
+type IRingDeque[T any] interface {
+	IRingQueue[T]
+
+	PushFront(element T) (newLen int, err error)
+	PopBack() (element T, newLen int, err error)
+	PeekBack() (element T, len int, err error)
+}
+
+// A queue whose push tells a dropped element (WhenFullDropNewest)
+// apart from a pushed one, or that never drops any, as DrainTo()
+// requires so as not to lose elements.
+type dropReporter[T any] interface {
+	pushDrop(elem T) (dropped bool, err error)
+}
+
 /* ----------------------------------------------------------------
  *				P u b l i c		T y p e s
  *-----------------------------------------------------------------*/
 
 type WhenEmpty int
 type WhenFull int
+type Decision int
 
 type OnCloseCallback[T any] func(data T)
+
+// Receives every element discarded by a full queue, whether evicted
+// by WhenFullOverwrite or dropped by WhenFullDropNewest.
+type OnEvictCallback[T any] func(evicted T)
+
+// Decides what to do with an element pushed onto a full queue when
+// WhenFullCustom. The queue must only be inspected, not modified.
+type FullPolicy[T any] func(queue IRingQueue[T], incoming T) Decision
+
+// Receives the size of the queue that crossed a watermark.
+type WatermarkCallback func(size int)
+
+// A position of the head taken by Mark(), to which the elements popped
+// afterwards can be returned until the mark is committed.
+type Mark struct {
+	at uint64 // elements popped before the mark was taken
+}
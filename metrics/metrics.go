@@ -0,0 +1,214 @@
+/* -----------------------------------------------------------------
+ *				   P u b l i c   D o m a i n / F O S
+ *				Copyright (C)2025 Dídimo Grimaldo T.
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * Publishes the statistics of named ring queues through expvar and
+ * in the Prometheus text exposition format, with nothing but the
+ * standard library.
+ *-----------------------------------------------------------------*/
+package metrics
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/lordofscripts/go-roundrobin"
+)
+
+/* ----------------------------------------------------------------
+ *				I n t e r f a c e s
+ *-----------------------------------------------------------------*/
+
+var _ expvar.Var = (*Registry)(nil)
+var _ http.Handler = (*Registry)(nil)
+
+// What the registry reads off a queue, whatever its element type.
+// The thread-safe queues also report their blocked Go routines.
+type Queue interface {
+	roundrobin.StatsQueue
+
+	Size() int
+	Cap() int
+}
+
+// Implemented by the thread-safe queues.
+type waitersQueue interface {
+	Waiters() int
+}
+
+/* ----------------------------------------------------------------
+ *						G l o b a l s
+ *-----------------------------------------------------------------*/
+
+var ( // module errors
+	ErrNoQueue        = fmt.Errorf("no queue to register")
+	ErrDuplicateQueue = fmt.Errorf("a queue is already registered under that name")
+)
+
+// the metrics in the order they are exposed
+var metrics = []struct {
+	name, kind, help string
+	value            func(v queueVars) int64
+}{
+	{"size", "gauge", "Elements in the queue.", func(v queueVars) int64 { return int64(v.Size) }},
+	{"capacity", "gauge", "Capacity of the queue.", func(v queueVars) int64 { return int64(v.Capacity) }},
+	{"pushes_total", "counter", "Elements pushed.", func(v queueVars) int64 { return int64(v.Pushes) }},
+	{"pops_total", "counter", "Elements popped.", func(v queueVars) int64 { return int64(v.Pops) }},
+	{"drops_total", "counter", "Incoming elements dropped by a full queue.", func(v queueVars) int64 { return int64(v.Drops) }},
+	{"overwrites_total", "counter", "Elements evicted by a full queue.", func(v queueVars) int64 { return int64(v.Overwrites) }},
+	{"rejections_total", "counter", "Elements refused by a full queue.", func(v queueVars) int64 { return int64(v.Rejections) }},
+	{"waiters", "gauge", "Go routines blocked in Push or Pop.", func(v queueVars) int64 { return int64(v.Waiters) }},
+}
+
+// escapes a label value of the exposition format
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		T y p e s
+ *-----------------------------------------------------------------*/
+
+// A set of named queues whose statistics it publishes. It is an
+// expvar.Var, to be published with expvar.Publish(), and an
+// http.Handler serving the Prometheus text exposition format.
+// The queues are read from the Go routine publishing them, hence
+// those used concurrently must be thread-safe ones.
+type Registry struct {
+	prefix string
+	mutex  sync.RWMutex
+	queues map[string]Queue
+}
+
+/* ----------------------------------------------------------------
+ *				P r i v a t e	T y p e s
+ *-----------------------------------------------------------------*/
+
+// the snapshot of a queue, as published by expvar
+type queueVars struct {
+	Size       int    `json:"size"`
+	Capacity   int    `json:"capacity"`
+	Pushes     uint64 `json:"pushes"`
+	Pops       uint64 `json:"pops"`
+	Drops      uint64 `json:"drops"`
+	Overwrites uint64 `json:"overwrites"`
+	Rejections uint64 `json:"rejections"`
+	Waiters    int    `json:"waiters"`
+}
+
+/* ----------------------------------------------------------------
+ *				C o n s t r u c t o r s
+ *-----------------------------------------------------------------*/
+
+// The metric names start with the prefix, e.g. "roundrobin_queue_".
+func NewRegistry(prefix string) *Registry {
+	return &Registry{
+		prefix: prefix,
+		queues: make(map[string]Queue),
+	}
+}
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		M e t h o d s
+ *-----------------------------------------------------------------*/
+
+func (r *Registry) Register(name string, q Queue) error {
+	if q == nil {
+		return ErrNoQueue
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, ok := r.queues[name]; ok {
+		return ErrDuplicateQueue
+	}
+
+	r.queues[name] = q
+	return nil
+}
+
+func (r *Registry) Unregister(name string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.queues, name)
+}
+
+// A JSON object with the statistics of each queue by name.
+// @implement expvar.Var
+func (r *Registry) String() string {
+	names, vars := r.snapshot()
+
+	byName := make(map[string]queueVars, len(names))
+	for i, name := range names {
+		byName[name] = vars[i]
+	}
+
+	res, _ := json.Marshal(byName)
+	return string(res)
+}
+
+// @implement http.Handler
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	r.WriteTo(w)
+}
+
+// Writes the statistics in the Prometheus text exposition format,
+// the queues in the order of their names.
+// @implement io.WriterTo
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	names, vars := r.snapshot()
+
+	var sb strings.Builder
+	for _, m := range metrics {
+		fmt.Fprintf(&sb, "# HELP %s%s %s\n", r.prefix, m.name, m.help)
+		fmt.Fprintf(&sb, "# TYPE %s%s %s\n", r.prefix, m.name, m.kind)
+		for i, name := range names {
+			fmt.Fprintf(&sb, "%s%s{queue=\"%s\"} %d\n", r.prefix, m.name, labelEscaper.Replace(name), m.value(vars[i]))
+		}
+	}
+
+	n, err := io.WriteString(w, sb.String())
+	return int64(n), err
+}
+
+/* ----------------------------------------------------------------
+ *				P r i v a t e	M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// the names in order along with the statistics of their queues
+func (r *Registry) snapshot() (names []string, vars []queueVars) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for name := range r.queues {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	vars = make([]queueVars, len(names))
+	for i, name := range names {
+		q := r.queues[name]
+		stats := q.Stats()
+		vars[i] = queueVars{
+			Size:       q.Size(),
+			Capacity:   q.Cap(),
+			Pushes:     stats.Pushes,
+			Pops:       stats.Pops,
+			Drops:      stats.Drops,
+			Overwrites: stats.Overwrites,
+			Rejections: stats.Rejections,
+		}
+		if wq, ok := q.(waitersQueue); ok {
+			vars[i].Waiters = wq.Waiters()
+		}
+	}
+
+	return names, vars
+}
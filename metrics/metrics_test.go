@@ -0,0 +1,89 @@
+/* -----------------------------------------------------------------
+ *				   P u b l i c   D o m a i n / F O S
+ *				Copyright (C)2025 Dídimo Grimaldo T.
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * Tests for the expvar and Prometheus exporter
+ *-----------------------------------------------------------------*/
+package metrics
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lordofscripts/go-roundrobin"
+)
+
+/* ----------------------------------------------------------------
+ *						T e s t s
+ *-----------------------------------------------------------------*/
+
+func Test_Registry(t *testing.T) {
+	jobs := roundrobin.NewSafeRingQueue[int](4, roundrobin.WhenFullDropNewest, roundrobin.WhenEmptyBlock, nil)
+	jobs.PushN([]int{1, 2, 3, 4, 5})
+	jobs.Pop()
+
+	runes := roundrobin.NewRuneRingQueue(2)
+	runes.SetWhenFull(roundrobin.WhenFullOverwrite)
+	runes.WriteString("ab")
+	runes.WriteString("c")
+
+	reg := NewRegistry("roundrobin_queue_")
+	if err := reg.Register("jobs", jobs); err != nil {
+		t.Fatalf("Register exp nil got %v", err)
+	}
+	if err := reg.Register(`"lexer"`, runes); err != nil {
+		t.Fatalf("Register exp nil got %v", err)
+	}
+	if err := reg.Register("jobs", runes); err != ErrDuplicateQueue {
+		t.Fatalf("Register twice exp ErrDuplicateQueue got %v", err)
+	}
+
+	// a consumer blocked on an empty queue
+	empty := roundrobin.NewSafeRingQueue[int](1, roundrobin.WhenFullError, roundrobin.WhenEmptyBlock, nil)
+	reg.Register("empty", empty)
+	go empty.Pop()
+	defer empty.Close()
+	for empty.Waiters() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// expvar
+	var vars map[string]queueVars
+	if err := json.Unmarshal([]byte(reg.String()), &vars); err != nil {
+		t.Fatalf("String() is not JSON: %v", err)
+	}
+	expected := queueVars{Size: 3, Capacity: 4, Pushes: 4, Pops: 1, Drops: 1}
+	if vars["jobs"] != expected {
+		t.Errorf("jobs mismatch, expected:%v, found:%v", expected, vars["jobs"])
+	}
+
+	// Prometheus
+	rec := httptest.NewRecorder()
+	reg.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("unexpected Content-Type %q", ct)
+	}
+	body, _ := io.ReadAll(rec.Body)
+	for _, line := range []string{
+		"# TYPE roundrobin_queue_size gauge",
+		"# TYPE roundrobin_queue_pushes_total counter",
+		`roundrobin_queue_size{queue="jobs"} 3`,
+		`roundrobin_queue_capacity{queue="\"lexer\""} 2`,
+		`roundrobin_queue_drops_total{queue="jobs"} 1`,
+		`roundrobin_queue_overwrites_total{queue="\"lexer\""} 1`,
+		`roundrobin_queue_waiters{queue="empty"} 1`,
+	} {
+		if !strings.Contains(string(body), line+"\n") {
+			t.Errorf("missing %q in:\n%s", line, body)
+		}
+	}
+
+	reg.Unregister("jobs")
+	if strings.Contains(reg.String(), `"jobs"`) {
+		t.Errorf("unregistered queue still published: %s", reg.String())
+	}
+}
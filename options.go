@@ -0,0 +1,246 @@
+/* -----------------------------------------------------------------
+ *				   P u b l i c   D o m a i n / F O S
+ *				Copyright (C)2025 Dídimo Grimaldo T.
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * A single constructor configured with functional options, which
+ * validates the whole configuration and reports what is wrong
+ * rather than returning nil.
+ *-----------------------------------------------------------------*/
+package roundrobin
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		T y p e s
+ *-----------------------------------------------------------------*/
+
+// Configures the queue built by New()
+type Option func(o *options)
+
+// Reports the option New() rejected and why. The reason can be
+// checked with errors.Is(), e.g. errors.Is(err, ErrBadDeadline).
+type OptionError struct {
+	Option string // name of the offending option
+	Value  any    // the rejected value
+	Err    error  // the reason
+}
+
+/* ----------------------------------------------------------------
+ *				P r i v a t e	T y p e s
+ *-----------------------------------------------------------------*/
+
+type options struct {
+	whenFull  WhenFull
+	whenEmpty WhenEmpty
+
+	// callbacks of the element type given to New()
+	onClose    any
+	onEvict    any
+	fullPolicy any
+
+	popDeadline  time.Time
+	pushDeadline time.Time
+
+	growFactor float64
+	maxCap     int
+
+	threadSafe bool
+}
+
+/* ----------------------------------------------------------------
+ *				C o n s t r u c t o r s
+ *-----------------------------------------------------------------*/
+
+/**
+ * A ring queue of the given capacity, by default a thread-safe one
+ * (*SafeRingQueue[T]) with WhenFullError and WhenEmptyError. Without
+ * thread-safety it is a plain *RingQueue[T], which cannot block.
+ */
+func New[T any](capacity int, opts ...Option) (IRingQueue[T], error) {
+	o := options{
+		whenFull:   WhenFullError,
+		whenEmpty:  WhenEmptyError,
+		growFactor: 2,
+		threadSafe: true,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if err := o.validate(capacity); err != nil {
+		return nil, err
+	}
+
+	onClose, err := callbackOf[OnCloseCallback[T]]("WithOnClose", o.onClose)
+	if err != nil {
+		return nil, err
+	}
+	onEvict, err := callbackOf[OnEvictCallback[T]]("WithOnEvict", o.onEvict)
+	if err != nil {
+		return nil, err
+	}
+	fullPolicy, err := callbackOf[FullPolicy[T]]("WithFullPolicy", o.fullPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	rq := NewRingQueue[T](capacity)
+	rq.SetWhenFull(o.whenFull)
+	rq.SetOnClose(onClose)
+	rq.SetOnEvict(onEvict)
+	rq.SetFullPolicy(fullPolicy)
+	rq.SetGrowth(o.growFactor, o.maxCap)
+	if !o.threadSafe {
+		return rq, nil
+	}
+
+	s := newSafeRingQueue(rq, o.whenFull, o.whenEmpty)
+	if !o.popDeadline.IsZero() {
+		s.SetPopDeadline(o.popDeadline)
+	}
+	if !o.pushDeadline.IsZero() {
+		s.SetPushDeadline(o.pushDeadline)
+	}
+
+	return s, nil
+}
+
+// What Push() does on a full queue, WhenFullError by default.
+func WithWhenFull(a WhenFull) Option {
+	return func(o *options) {
+		o.whenFull = a
+	}
+}
+
+// What Pop() does on an empty queue, WhenEmptyError by default.
+func WithWhenEmpty(a WhenEmpty) Option {
+	return func(o *options) {
+		o.whenEmpty = a
+	}
+}
+
+// The callback receiving the remaining elements on Close()
+func WithOnClose[T any](callback OnCloseCallback[T]) Option {
+	return func(o *options) {
+		o.onClose = callback
+	}
+}
+
+// The callback receiving the elements a full queue discards
+func WithOnEvict[T any](callback OnEvictCallback[T]) Option {
+	return func(o *options) {
+		o.onEvict = callback
+	}
+}
+
+// The policy of WhenFullCustom
+func WithFullPolicy[T any](policy FullPolicy[T]) Option {
+	return func(o *options) {
+		o.fullPolicy = policy
+	}
+}
+
+// The growth of WhenFullGrow, see RingQueue.SetGrowth()
+func WithGrowth(factor float64, maxCap int) Option {
+	return func(o *options) {
+		o.growFactor = factor
+		o.maxCap = maxCap
+	}
+}
+
+// Deadline of a blocking Pop(), requires WhenEmptyBlock.
+func WithPopDeadline(t time.Time) Option {
+	return func(o *options) {
+		o.popDeadline = t
+	}
+}
+
+// Deadline of a blocking Push(), requires WhenFullBlock.
+func WithPushDeadline(t time.Time) Option {
+	return func(o *options) {
+		o.pushDeadline = t
+	}
+}
+
+// Whether the queue may be shared by Go routines, true by default.
+func WithThreadSafe(threadSafe bool) Option {
+	return func(o *options) {
+		o.threadSafe = threadSafe
+	}
+}
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// @implements error
+func (e *OptionError) Error() string {
+	return fmt.Sprintf("roundrobin: %s(%v): %v", e.Option, e.Value, e.Err)
+}
+
+func (e *OptionError) Unwrap() error {
+	return e.Err
+}
+
+/* ----------------------------------------------------------------
+ *				P r i v a t e	M e t h o d s
+ *-----------------------------------------------------------------*/
+
+func (o *options) validate(capacity int) error {
+	if capacity <= 0 {
+		return &OptionError{"capacity", capacity, ErrBadCapacity}
+	}
+
+	if o.whenFull < WhenFullError || o.whenFull > WhenFullCustom {
+		return &OptionError{"WithWhenFull", o.whenFull, ErrBadOption}
+	}
+
+	if o.whenEmpty != WhenEmptyError && o.whenEmpty != WhenEmptyBlock {
+		return &OptionError{"WithWhenEmpty", o.whenEmpty, ErrBadOption}
+	}
+
+	// only the thread-safe queue can wait for another Go routine
+	if !o.threadSafe && o.whenFull == WhenFullBlock {
+		return &OptionError{"WithWhenFull", o.whenFull, errors.ErrUnsupported}
+	}
+
+	if !o.threadSafe && o.whenEmpty == WhenEmptyBlock {
+		return &OptionError{"WithWhenEmpty", o.whenEmpty, errors.ErrUnsupported}
+	}
+
+	if o.growFactor <= 1 {
+		return &OptionError{"WithGrowth", o.growFactor, ErrBadGrowth}
+	}
+
+	if !o.popDeadline.IsZero() && (!o.threadSafe || o.whenEmpty != WhenEmptyBlock) {
+		return &OptionError{"WithPopDeadline", o.popDeadline, ErrBadDeadline}
+	}
+
+	if !o.pushDeadline.IsZero() && (!o.threadSafe || o.whenFull != WhenFullBlock) {
+		return &OptionError{"WithPushDeadline", o.pushDeadline, ErrBadPushDeadline}
+	}
+
+	return nil
+}
+
+/* ----------------------------------------------------------------
+ *					F u n c t i o n s
+ *-----------------------------------------------------------------*/
+
+// a callback given as an option, which must match the element type
+func callbackOf[C any](option string, callback any) (C, error) {
+	var none C
+	if callback == nil {
+		return none, nil
+	}
+
+	if cb, ok := callback.(C); ok {
+		return cb, nil
+	}
+
+	return none, &OptionError{option, fmt.Sprintf("%T", callback), ErrBadOption}
+}
@@ -0,0 +1,87 @@
+/* -----------------------------------------------------------------
+ *				   P u b l i c   D o m a i n / F O S
+ *				Copyright (C)2025 Dídimo Grimaldo T.
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * Tests for the option-based constructor
+ *-----------------------------------------------------------------*/
+package roundrobin
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+/* ----------------------------------------------------------------
+ *						T e s t s
+ *-----------------------------------------------------------------*/
+
+func Test_New(t *testing.T) {
+	closed := 0
+	obj, err := New[int](2,
+		WithWhenFull(WhenFullBlock),
+		WithWhenEmpty(WhenEmptyBlock),
+		WithOnClose(func(int) { closed++ }),
+		WithPushDeadline(time.Now().Add(50*time.Millisecond)))
+	if err != nil {
+		t.Fatalf("unexpected New error: %v", err)
+	}
+	if _, ok := obj.(*SafeRingQueue[int]); !ok {
+		t.Fatalf("expected a *SafeRingQueue[int], got %T", obj)
+	}
+
+	obj.Push(1)
+	obj.Push(2)
+	if _, err := obj.Push(3); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected DeadlineExceeded error, got %v", err)
+	}
+	obj.Pop()
+	obj.Close()
+	if closed != 1 {
+		t.Errorf("onClose called %d times, expected 1", closed)
+	}
+
+	plain, err := New[string](2, WithThreadSafe(false), WithWhenFull(WhenFullOverwrite))
+	if err != nil {
+		t.Fatalf("unexpected New error: %v", err)
+	}
+	if _, ok := plain.(*RingQueue[string]); !ok {
+		t.Errorf("expected a *RingQueue[string], got %T", plain)
+	}
+}
+
+func Test_NewErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity int
+		opts     []Option
+		option   string
+		err      error
+	}{
+		{"capacity", 0, nil, "capacity", ErrBadCapacity},
+		{"whenFull", 1, []Option{WithWhenFull(WhenFull(99))}, "WithWhenFull", ErrBadOption},
+		{"whenEmpty", 1, []Option{WithWhenEmpty(WhenEmpty(-1))}, "WithWhenEmpty", ErrBadOption},
+		{"blocking plain", 1, []Option{WithThreadSafe(false), WithWhenEmpty(WhenEmptyBlock)}, "WithWhenEmpty", errors.ErrUnsupported},
+		{"growth", 1, []Option{WithGrowth(0.5, 0)}, "WithGrowth", ErrBadGrowth},
+		{"pop deadline", 1, []Option{WithPopDeadline(time.Now())}, "WithPopDeadline", ErrBadDeadline},
+		{"push deadline", 1, []Option{WithPushDeadline(time.Now())}, "WithPushDeadline", ErrBadPushDeadline},
+		{"callback type", 1, []Option{WithOnClose(func(string) {})}, "WithOnClose", ErrBadOption},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := New[int](tt.capacity, tt.opts...)
+			if obj != nil {
+				t.Errorf("expected no queue, got %v", obj)
+			}
+
+			var optErr *OptionError
+			if !errors.As(err, &optErr) || optErr.Option != tt.option {
+				t.Fatalf("expected an *OptionError on %s, got %v", tt.option, err)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
@@ -8,8 +8,13 @@
 package roundrobin
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"iter"
+	"math"
+	"slices"
+	"sort"
 	"sync"
 	"time"
 )
@@ -19,6 +24,10 @@ import (
  *-----------------------------------------------------------------*/
 
 var _ IRingQueue[int] = (*RingQueue[int])(nil)
+var _ ContextQueue[int] = (*RingQueue[int])(nil)
+var _ IRingDeque[int] = (*RingQueue[int])(nil)
+var _ StatsQueue = (*RingQueue[int])(nil)
+var _ WatermarkQueue = (*RingQueue[int])(nil)
 
 /* ----------------------------------------------------------------
  *				P u b l i c		T y p e s
@@ -31,10 +40,29 @@ type RingQueue[T any] struct {
 	end   int // end index (exclusive, i.e. next after last element)
 
 	// Hadi's enhancements
-	whenFull  WhenFull
-	closed    bool
-	onClose   OnCloseCallback[T]
-	closeOnce sync.Once
+	whenFull   WhenFull
+	closed     bool
+	onClose    OnCloseCallback[T]
+	onEvict    OnEvictCallback[T]
+	fullPolicy FullPolicy[T]
+	closeOnce  sync.Once
+
+	// Sequence numbers
+	seq  uint64   // that of the newest element, i.e. how many were pushed
+	seqs []uint64 // that of the element in each slot
+
+	stats  statsBlock
+	levels watermarks
+	hooks  Hooks[T]
+
+	// Mark()
+	reads uint64   // elements popped from the head so far
+	marks []uint64 // reads at each uncommitted mark, oldest first
+
+	// WhenFullGrow
+	baseCap    int     // capacity Shrink() returns to
+	growFactor float64 // capacity multiplier on each growth
+	maxCap     int     // hard limit of the growth, zero for none
 }
 
 /* ----------------------------------------------------------------
@@ -44,12 +72,16 @@ type RingQueue[T any] struct {
 func NewRingQueue[T any](capacity int) *RingQueue[T] {
 	return &RingQueue[T]{
 		data:  make([]T, capacity),
+		seqs:  make([]uint64, capacity),
 		count: NewSafeCounter(),
 		start: 0,
 		end:   0,
 
 		whenFull: WhenFullError,
 		closed:   false,
+
+		baseCap:    capacity,
+		growFactor: 2,
 	}
 }
 
@@ -67,11 +99,130 @@ func (r *RingQueue[T]) SetOnClose(callback OnCloseCallback[T]) IRingQueue[T] {
 	return r
 }
 
+// Replaces the hooks installed so far, OnClose included.
+func (r *RingQueue[T]) SetHooks(hooks Hooks[T]) IRingQueue[T] {
+	r.hooks = hooks
+	return r.SetOnClose(hooks.OnClose)
+}
+
+// Sets the callback receiving each element a full queue discards:
+// the oldest ones WhenFullOverwrite, including those evicted by Resize()
+// and dropped by a PushN() larger than the capacity, and the incoming
+// ones WhenFullDropNewest.
+func (r *RingQueue[T]) SetOnEvict(callback OnEvictCallback[T]) IRingQueue[T] {
+	r.onEvict = callback
+	return r
+}
+
+// Sets the policy deciding, for each element pushed onto a full queue,
+// what WhenFullCustom does. Without a policy it fails with ErrFullQueue.
+func (r *RingQueue[T]) SetFullPolicy(policy FullPolicy[T]) IRingQueue[T] {
+	r.fullPolicy = policy
+	return r
+}
+
+// Configures WhenFullGrow: a full queue multiplies its capacity by
+// factor, never beyond maxCap (zero means no limit).
+func (r *RingQueue[T]) SetGrowth(factor float64, maxCap int) error {
+	if factor <= 1 {
+		return ErrBadGrowth
+	}
+
+	r.growFactor = factor
+	r.maxCap = max(maxCap, 0)
+	return nil
+}
+
+// Changes the capacity keeping the elements in FIFO order, and makes
+// it the base capacity Shrink() returns to. If the elements do not
+// fit, WhenFullOverwrite evicts the oldest ones, otherwise the queue
+// is left untouched and ErrFullQueue is returned.
+func (r *RingQueue[T]) Resize(newCap int) error {
+	if r.closed {
+		return ErrClosed
+	}
+
+	if newCap <= 0 {
+		return ErrBadCapacity
+	}
+
+	if err := r.resize(newCap); err != nil {
+		return err
+	}
+
+	r.baseCap = newCap
+	return nil
+}
+
+// Gives back the memory taken by WhenFullGrow, shrinking toward the
+// base capacity but never below the current size.
+func (r *RingQueue[T]) Shrink() error {
+	if r.closed {
+		return ErrClosed
+	}
+
+	if newCap := max(r.baseCap, r.Size()+r.held()); newCap < len(r.data) {
+		return r.resize(newCap)
+	}
+
+	return nil
+}
+
+// Empties the queue, which fires the low watermark callback if the
+// high one fired last.
 func (r *RingQueue[T]) Reset() {
-	r.start = 0
-	r.end = 0
-	r.count.Clear()
-	clear(r.data)
+	discarded := r.Size()
+	r.empty()
+	r.hooks.reset(discarded)
+	r.levels.fell(0)
+}
+
+// Like Reset() but first hands each element over to fn in FIFO order,
+// so that none is lost without notice. fn must not use the queue.
+func (r *RingQueue[T]) ResetFunc(fn func(T)) {
+	if fn != nil {
+		for i := range r.Size() {
+			fn(r.data[(r.start+i)%len(r.data)])
+		}
+	}
+
+	r.Reset()
+}
+
+// Pops every element onto dst, another queue, in FIFO order and then
+// empties the queue. If dst refuses an element, that one and those
+// after it stay queued and its error is returned, ErrDropped if dst
+// dropped it (WhenFullDropNewest). A dst that cannot tell whether it
+// dropped an element, such as a RuneRingQueue, is refused with
+// errors.ErrUnsupported. It returns how many elements were moved.
+func (r *RingQueue[T]) DrainTo(dst IRingQueue[T]) (n int, err error) {
+	if r.closed {
+		return 0, ErrClosed
+	}
+
+	if dst == IRingQueue[T](r) {
+		return 0, ErrSelfDrain
+	}
+
+	pusher, ok := dst.(dropReporter[T])
+	if !ok {
+		return 0, errors.ErrUnsupported
+	}
+
+	for !r.count.IsZero() {
+		dropped, err := pusher.pushDrop(r.data[r.start])
+		if err != nil {
+			return n, err
+		}
+		if dropped {
+			return n, ErrDropped // it stays queued
+		}
+		r.Pop()
+		n++
+	}
+
+	r.empty()
+	return n, nil
 }
 
 // @implements fmt.Stringer interface
@@ -86,6 +237,46 @@ func (r *RingQueue[T]) String() string {
 }
 
 func (r *RingQueue[T]) Push(elem T) (int, error) {
+	newLen, _, _, err := r.pushEvict(elem)
+	return newLen, err
+}
+
+// Like Push(), returning the sequence number stamped on the element:
+// one for the first element ever pushed, and one more for each of the
+// following ones. It is zero if the full queue dropped the element.
+func (r *RingQueue[T]) PushSeq(elem T) (seq uint64, err error) {
+	before := r.seq
+	if _, _, _, err = r.pushEvict(elem); err != nil || r.seq == before {
+		return 0, err
+	}
+
+	return r.seq, nil
+}
+
+// Like Push(), also reporting the element the full queue discarded:
+// the oldest one with WhenFullOverwrite or the incoming one with
+// WhenFullDropNewest.
+func (r *RingQueue[T]) PushEvict(elem T) (evicted T, didEvict bool, err error) {
+	_, evicted, didEvict, err = r.pushEvict(elem)
+	return
+}
+
+// Push() unless the context is already done. A plain queue never
+// blocks, therefore the context is only checked once.
+func (r *RingQueue[T]) PushCtx(ctx context.Context, elem T) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return r.Push(elem)
+}
+
+// Pushes onto the front so that it becomes the next one to Pop().
+// On a full queue WhenFullOverwrite evicts the newest element.
+// The element gets the next sequence number like any other push,
+// which puts the numbers out of FIFO order, hence it should not be
+// used on a queue read through Since() or cursors.
+func (r *RingQueue[T]) PushFront(elem T) (int, error) {
 	if r.closed {
 		return 0, ErrClosed
 	}
@@ -93,31 +284,121 @@ func (r *RingQueue[T]) Push(elem T) (int, error) {
 	noIncrement := false
 	var newLen int
 	if r.IsFull() {
-		switch r.whenFull {
-		case WhenFullError:
+		switch r.policyFor(elem) {
+		case WhenFullError, WhenFullBlock:
+			r.stats.rejected(1)
 			return r.Size(), ErrFullQueue
 
+		case WhenFullDropNewest:
+			r.evictAll([]T{elem})
+			return r.Size(), nil
+
 		case WhenFullOverwrite:
-			// continue pushing with loss of data
-			// the OLDEST data gets overwritten as
-			// fresher data is prioritized.
+			if r.count.IsZero() {
+				r.stats.rejected(1)
+				return 0, ErrFullQueue // all held by a mark
+			}
+
+			// drop the newest to make room at the front
 			noIncrement = true
-			newLen = len(r.data)
+			newLen = r.Size()
+			r.end = (r.end - 1 + len(r.data)) % len(r.data)
+			r.evict(r.end, 1)
+
+		case WhenFullGrow:
+			if !r.grow(1) {
+				r.stats.rejected(1)
+				return r.Size(), ErrFullQueue
+			}
 
 		default:
 			return len(r.data), errors.ErrUnsupported
 		}
 	}
 
-	r.data[r.end] = elem              // place the new element on the available space
-	r.end = (r.end + 1) % len(r.data) // move the end forward by modulo of capacity
+	// the elements held by a mark stay right before the start
+	r.moveHeld(-1)
+	r.start = (r.start - 1 + len(r.data)) % len(r.data) // move the start backward
+	r.data[r.start] = elem
+	r.seq++
+	r.seqs[r.start] = r.seq
 	if !noIncrement {
 		newLen = int(r.count.Increment())
 	}
+	r.pushedAt(r.start, 1)
 
 	return newLen, nil
 }
 
+// Pushes a batch of elements with at most two copy() calls. If the
+// batch does not fit, WhenFullError writes what fits and returns
+// ErrFullQueue, while WhenFullOverwrite keeps the newest elements.
+// WhenFullGrow grows once to fit the batch, up to its maximum.
+// WhenFullDropNewest writes what fits without error, and with
+// WhenFullCustom the policy decides for each element that does not fit.
+func (r *RingQueue[T]) PushN(elems []T) (written int, err error) {
+	if r.closed {
+		return 0, ErrClosed
+	}
+
+	free := len(r.data) - r.Size() - r.held()
+	if len(elems) > free && r.whenFull == WhenFullGrow {
+		r.grow(len(elems) - free)
+		free = len(r.data) - r.Size() - r.held()
+	}
+
+	if len(elems) > free {
+		switch r.whenFull {
+		case WhenFullError, WhenFullBlock, WhenFullGrow:
+			r.write(elems[:free])
+			r.count.Add(int64(free))
+			r.pushed(free)
+			r.stats.rejected(len(elems) - free)
+			return free, ErrFullQueue
+
+		case WhenFullDropNewest:
+			r.write(elems[:free])
+			r.count.Add(int64(free))
+			r.pushed(free)
+			r.evictAll(elems[free:])
+			return free, nil
+
+		case WhenFullCustom:
+			return r.pushEach(elems, free)
+
+		case WhenFullOverwrite:
+			if r.held() > 0 {
+				// Push() spares the elements held by a mark
+				return r.pushEach(elems, free)
+			}
+
+			// only the last Cap() elements survive anyway
+			if len(elems) > len(r.data) {
+				r.evict((r.end+free)%len(r.data), len(r.data)-free)
+				r.evictAll(elems[:len(elems)-len(r.data)])
+				r.seq += uint64(len(elems) - len(r.data)) // lost right away
+				r.write(elems[len(elems)-len(r.data):])
+			} else {
+				r.evict((r.end+free)%len(r.data), len(elems)-free)
+				r.write(elems)
+			}
+			r.start = r.end // full, so end wrapped onto start
+			r.count.Add(int64(free))
+			r.pushed(min(len(elems), len(r.data)))
+			return len(elems), nil
+
+		default:
+			return 0, errors.ErrUnsupported
+		}
+	}
+
+	r.write(elems)
+	r.count.Add(int64(len(elems)))
+	r.pushed(len(elems))
+
+	return len(elems), nil
+}
+
 func (r *RingQueue[T]) Pop() (T, int, error) {
 	var res T // "zero" element (respective of the type)
 	if r.closed {
@@ -131,10 +412,72 @@ func (r *RingQueue[T]) Pop() (T, int, error) {
 	res = r.data[r.start]                 // copy over the first element in the queue
 	r.start = (r.start + 1) % len(r.data) // move the start of the queue
 	newLen := r.count.Decrement()
+	r.reads++
+	r.popped(1)
+
+	return res, int(newLen), nil
+}
+
+// Pop() unless the context is already done. A plain queue never
+// blocks, therefore the context is only checked once.
+func (r *RingQueue[T]) PopCtx(ctx context.Context) (T, int, error) {
+	if err := ctx.Err(); err != nil {
+		var res T
+		return res, 0, err
+	}
+
+	return r.Pop()
+}
+
+// Pops the newest element, i.e. the one a Push() just added.
+func (r *RingQueue[T]) PopBack() (T, int, error) {
+	var res T // "zero" element (respective of the type)
+	if r.closed {
+		return res, 0, ErrClosed
+	}
+
+	if r.count.IsZero() {
+		return res, 0, ErrEmptyQueue
+	}
+
+	r.end = (r.end - 1 + len(r.data)) % len(r.data) // move the end backward
+	res = r.data[r.end]
+	newLen := r.count.Decrement()
+	r.poppedAt(r.end, 1)
 
 	return res, int(newLen), nil
 }
 
+// Pops up to len(dst) elements into dst with at most two copy() calls.
+// It returns ErrEmptyQueue only when there was nothing to pop.
+func (r *RingQueue[T]) PopN(dst []T) (n int, err error) {
+	if r.closed {
+		return 0, ErrClosed
+	}
+
+	if len(dst) == 0 {
+		return 0, nil
+	}
+
+	if r.count.IsZero() {
+		return 0, ErrEmptyQueue
+	}
+
+	n = min(len(dst), r.Size())
+	r.read(dst[:n])
+	r.start = (r.start + n) % len(r.data)
+	r.count.Add(int64(-n))
+	r.reads += uint64(n)
+	r.popped(n)
+
+	return n, nil
+}
+
+// Same as PopN(), filling dst with the oldest elements.
+func (r *RingQueue[T]) PopInto(dst []T) (n int, err error) {
+	return r.PopN(dst)
+}
+
 func (r *RingQueue[T]) Peek() (T, int, error) {
 	var res T // "zero" element (respective of the type)
 	if r.closed {
@@ -148,6 +491,58 @@ func (r *RingQueue[T]) Peek() (T, int, error) {
 	return r.data[r.start], int(r.count.Value()), nil
 }
 
+// Peeks the newest element, i.e. the one a Push() just added.
+func (r *RingQueue[T]) Back() (T, int, error) {
+	var res T // "zero" element (respective of the type)
+	if r.closed {
+		return res, 0, ErrClosed
+	}
+
+	if r.count.IsZero() {
+		return res, 0, ErrEmptyQueue
+	}
+
+	return r.data[(r.end-1+len(r.data))%len(r.data)], int(r.count.Value()), nil
+}
+
+// Same as Back(), completes the IRingDeque interface.
+func (r *RingQueue[T]) PeekBack() (T, int, error) {
+	return r.Back()
+}
+
+// The element at logical position i without popping it. Zero is the
+// oldest element, negative positions count from the newest (-1).
+func (r *RingQueue[T]) At(i int) (T, error) {
+	var res T // "zero" element (respective of the type)
+	if r.closed {
+		return res, ErrClosed
+	}
+
+	size := r.Size()
+	if i < 0 {
+		i += size
+	}
+	if i < 0 || i >= size {
+		return res, ErrOutOfRange
+	}
+
+	return r.data[(r.start+i)%len(r.data)], nil
+}
+
+// A copy of the elements in FIFO order.
+func (r *RingQueue[T]) ToSlice() []T {
+	return r.AppendTo(nil)
+}
+
+// Appends the elements in FIFO order to dst, growing it if needed.
+func (r *RingQueue[T]) AppendTo(dst []T) []T {
+	n, size := len(dst), r.Size()
+	dst = slices.Grow(dst, size)[:n+size]
+	r.read(dst[n:])
+
+	return dst
+}
+
 func (r *RingQueue[T]) Size() int {
 	if r.closed {
 		return 0
@@ -173,6 +568,54 @@ func (r *RingQueue[T]) Cap() int {
 	return len(r.data)
 }
 
+// Iterates over (logical index, element) pairs in FIFO order, i.e.
+// from the oldest (index 0) to the newest element, without popping.
+// The queue must not be modified during the iteration.
+func (r *RingQueue[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range r.Size() {
+			if !yield(i, r.data[(r.start+i)%len(r.data)]) {
+				return
+			}
+		}
+	}
+}
+
+// Iterates over the elements in FIFO order without popping them.
+func (r *RingQueue[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range r.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Like All() but from the newest to the oldest element.
+func (r *RingQueue[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := r.Size() - 1; i >= 0; i-- {
+			if !yield(i, r.data[(r.start+i)%len(r.data)]) {
+				return
+			}
+		}
+	}
+}
+
+// Pops the elements as it iterates, stopping when the queue is empty.
+// Elements not reached after a break remain in the queue.
+func (r *RingQueue[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			v, _, err := r.Pop()
+			if err != nil || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
 func (r *RingQueue[T]) IsFull() bool {
 	if r.closed {
 		return false
@@ -180,7 +623,110 @@ func (r *RingQueue[T]) IsFull() bool {
 
 	// since Ctor(capacity) is an int, this cast will never go wrong
 	// @note unless the Ctor capacity type is changed to int64
-	return len(r.data) == int(r.count.Value())
+	return len(r.data) == int(r.count.Value())+r.held()
+}
+
+// Marks the current head: the elements popped from now on stay in
+// their slots, where not even WhenFullOverwrite evicts them, so that
+// ResetToMark() can return them to the head. Marks may be nested.
+func (r *RingQueue[T]) Mark() Mark {
+	m := Mark{at: r.reads}
+	r.marks = append(r.marks, m.at)
+	return m
+}
+
+// Returns the elements popped since the mark to the head, in their
+// order, discarding the marks taken after it. The mark itself stays
+// active, it may be reset to again until committed.
+func (r *RingQueue[T]) ResetToMark(m Mark) error {
+	if r.closed {
+		return ErrClosed
+	}
+
+	i := slices.Index(r.marks, m.at)
+	if i < 0 {
+		return ErrBadMark
+	}
+
+	if n := int(r.reads - m.at); n > 0 {
+		r.start = (r.start - n + len(r.data)) % len(r.data)
+		r.count.Add(int64(n))
+		r.reads = m.at
+		r.pushed(0) // not counted as pushes again
+	}
+	r.marks = r.marks[:i+1]
+
+	return nil
+}
+
+// Releases the mark, the elements popped since are gone for good
+// unless an older mark still holds them.
+func (r *RingQueue[T]) Commit(m Mark) {
+	if i := slices.Index(r.marks, m.at); i >= 0 {
+		r.marks = slices.Delete(r.marks, i, i+1)
+	}
+}
+
+// A snapshot of the statistics, which unlike the other methods may be
+// taken while another Go routine uses the queue.
+// @implement roundrobin.StatsQueue
+func (r *RingQueue[T]) Stats() QueueStats {
+	return r.stats.snapshot()
+}
+
+// Starts the statistics over, the high-water mark from the current size.
+// @implement roundrobin.StatsQueue
+func (r *RingQueue[T]) ResetStats() {
+	r.stats.reset(r.Size())
+}
+
+// onHigh fires once a push takes the size up to high, after which
+// onLow fires once a pop takes it under low, and so on. A high mark
+// of zero disables them. The callbacks run inside the push or pop.
+// @implement roundrobin.WatermarkQueue
+func (r *RingQueue[T]) SetWatermarks(high, low int, onHigh, onLow WatermarkCallback) error {
+	return r.levels.set(high, low, onHigh, onLow, r.Size())
+}
+
+// The sequence number of the oldest element queued, one more than
+// NewestSeq() when empty.
+func (r *RingQueue[T]) OldestSeq() uint64 {
+	if r.count.IsZero() || r.closed {
+		return r.seq + 1
+	}
+
+	return r.seqAt(0)
+}
+
+// The sequence number of the last element pushed, even if it was
+// popped since. Zero if nothing was ever pushed. Numbers are never
+// reused, not even those of the elements PopBack() takes back.
+func (r *RingQueue[T]) NewestSeq() uint64 {
+	return r.seq
+}
+
+// A copy of the elements pushed after the one with the given sequence
+// number, in FIFO order, so that a consumer can resume where it left
+// off. missed tells how many of them were popped or overwritten meanwhile.
+func (r *RingQueue[T]) Since(seq uint64) (elems []T, missed uint64) {
+	if seq >= r.seq {
+		return nil, 0
+	}
+
+	if first := r.seqIndex(seq + 1); first < r.Size() {
+		elems = make([]T, r.Size()-first)
+		for i := range elems {
+			elems[i] = r.data[(r.start+first+i)%len(r.data)]
+		}
+	}
+
+	return elems, r.seq - seq - uint64(len(elems))
+}
+
+// A reader of its own that goes through the elements without popping
+// them, starting with the oldest one.
+func (r *RingQueue[T]) NewCursor() *Cursor[T] {
+	return newCursor(r, nil)
 }
 
 func (r *RingQueue[T]) SetPopDeadline(t time.Time) error {
@@ -190,15 +736,319 @@ func (r *RingQueue[T]) SetPopDeadline(t time.Time) error {
 // @implement io.Closer
 func (r *RingQueue[T]) Close() error {
 	r.closeOnce.Do(func() {
-		r.closed = true
 		if r.onClose != nil {
-			for (r.end - r.start) != 0 {
+			for range r.Size() {
 				res := r.data[r.start]
 				r.start = (r.start + 1) % len(r.data)
 				r.onClose(res)
 			}
 		}
+		r.closed = true
 		r.data = nil
+		r.seqs = nil
 	})
 	return nil
 }
+
+/* ----------------------------------------------------------------
+ *				P r i v a t e	M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// Push() also reporting what a full queue discarded, be it the oldest
+// element (WhenFullOverwrite) or the incoming one (WhenFullDropNewest).
+func (r *RingQueue[T]) pushEvict(elem T) (newLen int, evicted T, didEvict bool, err error) {
+	if r.closed {
+		return 0, evicted, false, ErrClosed
+	}
+
+	noIncrement := false
+	if r.IsFull() {
+		switch r.policyFor(elem) {
+		case WhenFullError, WhenFullBlock:
+			// a plain queue cannot block, the thread-safe
+			// wrapper waits on ErrFullQueue when blocking.
+			r.stats.rejected(1)
+			return r.Size(), evicted, false, ErrFullQueue
+
+		case WhenFullDropNewest:
+			// the OLDEST data is prioritized, the
+			// incoming element is dropped silently.
+			r.evictAll([]T{elem})
+			return r.Size(), elem, true, nil
+
+		case WhenFullOverwrite:
+			if r.held() > 0 {
+				// the elements held by a mark are spared,
+				// the oldest one still queued goes instead.
+				if r.count.IsZero() {
+					r.stats.rejected(1)
+					return 0, evicted, false, ErrFullQueue
+				}
+				evicted, didEvict = r.data[r.start], true
+				r.evictHeld(1)
+				break
+			}
+
+			// continue pushing with loss of data
+			// the OLDEST data gets overwritten as
+			// fresher data is prioritized.
+			noIncrement = true
+			newLen = len(r.data)
+			evicted, didEvict = r.data[r.end], true
+			r.evict(r.end, 1)
+
+		case WhenFullGrow:
+			if !r.grow(1) {
+				r.stats.rejected(1)
+				return r.Size(), evicted, false, ErrFullQueue
+			}
+
+		default:
+			return len(r.data), evicted, false, errors.ErrUnsupported
+		}
+	}
+
+	r.data[r.end] = elem // place the new element on the available space
+	r.seq++
+	r.seqs[r.end] = r.seq
+	r.end = (r.end + 1) % len(r.data) // move the end forward by modulo of capacity
+	if noIncrement {
+		r.start = r.end // the oldest was overwritten, the next one is now first
+	} else {
+		newLen = int(r.count.Increment())
+	}
+	r.pushed(1)
+
+	return newLen, evicted, didEvict, nil
+}
+
+// Push() telling whether the full queue dropped the element, which
+// then got no sequence number.
+// @implement roundrobin.dropReporter[T]
+func (r *RingQueue[T]) pushDrop(elem T) (dropped bool, err error) {
+	before := r.seq
+	if _, _, _, err = r.pushEvict(elem); err != nil {
+		return false, err
+	}
+
+	return r.seq == before, nil
+}
+
+// empties the queue without notice
+func (r *RingQueue[T]) empty() {
+	r.marks = nil
+	r.start = 0
+	r.end = 0
+	r.count.Clear()
+	clear(r.data)
+}
+
+// the WhenFull applying to an element pushed onto a full queue,
+// which with WhenFullCustom is up to the user-defined policy.
+func (r *RingQueue[T]) policyFor(elem T) WhenFull {
+	if r.whenFull != WhenFullCustom {
+		return r.whenFull
+	}
+
+	if r.fullPolicy == nil {
+		return WhenFullError
+	}
+
+	switch r.fullPolicy(r, elem) {
+	case DecisionDrop:
+		return WhenFullDropNewest
+	case DecisionOverwrite:
+		return WhenFullOverwrite
+	default:
+		return WhenFullError
+	}
+}
+
+// reallocates the data with newCap slots, evicting the oldest elements
+// if WhenFullOverwrite and they do not fit, though never those held by
+// a mark. The elements are moved to the beginning of the new data.
+func (r *RingQueue[T]) resize(newCap int) error {
+	size, held := r.Size(), r.held()
+	if newCap < size+held {
+		if r.whenFull != WhenFullOverwrite || newCap < held {
+			return ErrFullQueue
+		}
+
+		r.evictHeld(size + held - newCap)
+		size = newCap - held
+	}
+
+	data, seqs := make([]T, newCap), make([]uint64, newCap)
+	r.start = (r.start - held + len(r.data)) % max(len(r.data), 1)
+	r.read(data[:held+size])
+	n := copy(seqs[:held+size], r.seqs[r.start:])
+	copy(seqs[n:held+size], r.seqs)
+	r.data, r.seqs = data, seqs
+	r.start = held % newCap
+	r.end = (held + size) % newCap
+
+	return nil
+}
+
+// the elements popped since the oldest uncommitted mark, which
+// remain in the slots right before the start.
+func (r *RingQueue[T]) held() int {
+	if len(r.marks) == 0 {
+		return 0
+	}
+
+	return int(r.reads - r.marks[0])
+}
+
+// moves the elements held by a mark n slots forward (or backward if
+// negative), onto slots that must be free.
+func (r *RingQueue[T]) moveHeld(n int) {
+	held := r.held()
+	if held == 0 || n == 0 {
+		return
+	}
+
+	base, size := r.start-held+len(r.data), len(r.data)
+	for i := range held {
+		if n > 0 {
+			i = held - 1 - i // from the newest, not to overwrite the next
+		}
+		r.data[(base+i+n+size)%size] = r.data[(base+i)%size]
+		r.seqs[(base+i+n+size)%size] = r.seqs[(base+i)%size]
+	}
+}
+
+// evicts the n oldest elements still queued, moving those held by a
+// mark forward so that they stay right before the start.
+func (r *RingQueue[T]) evictHeld(n int) {
+	r.evict(r.start, n)
+	r.moveHeld(n)
+	r.start = (r.start + n) % len(r.data)
+	r.count.Add(int64(-n))
+}
+
+// writes what fits and then Push()es the rest one by one, leaving
+// the decision for each of them to the WhenFull behavior.
+func (r *RingQueue[T]) pushEach(elems []T, free int) (written int, err error) {
+	r.write(elems[:free])
+	r.count.Add(int64(free))
+	r.pushed(free)
+	for i, elem := range elems[free:] {
+		if _, err = r.Push(elem); err != nil {
+			return free + i, err
+		}
+	}
+
+	return len(elems), nil
+}
+
+// accounts for the n elements just pushed at the end
+func (r *RingQueue[T]) pushed(n int) {
+	r.pushedAt((r.end-n+len(r.data))%len(r.data), n)
+}
+
+// accounts for the n elements just pushed from position pos onward
+func (r *RingQueue[T]) pushedAt(pos, n int) {
+	r.stats.pushed(n, r.Size())
+	for i := range n {
+		r.hooks.push(r.data[(pos+i)%len(r.data)])
+	}
+	r.levels.rose(r.Size())
+}
+
+// accounts for the n elements just popped from the start
+func (r *RingQueue[T]) popped(n int) {
+	r.poppedAt((r.start-n+len(r.data))%len(r.data), n)
+}
+
+// accounts for the n elements just popped from position pos onward,
+// whose slots still hold them.
+func (r *RingQueue[T]) poppedAt(pos, n int) {
+	r.stats.popped(n)
+	for i := range n {
+		r.hooks.pop(r.data[(pos+i)%len(r.data)])
+	}
+	r.levels.fell(r.Size())
+}
+
+// hands n elements from position pos onward over to the eviction
+// callback, before they get overwritten.
+func (r *RingQueue[T]) evict(pos, n int) {
+	r.stats.overwritten(n)
+	for i := range n {
+		evicted := r.data[(pos+i)%len(r.data)]
+		r.hooks.overwrite(evicted)
+		if r.onEvict != nil {
+			r.onEvict(evicted)
+		}
+	}
+}
+
+// hands incoming elements that never made it into the queue over to
+// the eviction callback.
+func (r *RingQueue[T]) evictAll(elems []T) {
+	r.stats.dropped(len(elems))
+	if r.onEvict == nil {
+		return
+	}
+
+	for _, elem := range elems {
+		r.onEvict(elem)
+	}
+}
+
+// grows the capacity by the growth factor, or more if that does not
+// make room for need elements, without exceeding the maximum.
+// It returns false if the capacity could not grow at all.
+func (r *RingQueue[T]) grow(need int) bool {
+	newCap := int(math.Ceil(float64(len(r.data)) * r.growFactor))
+	newCap = max(newCap, len(r.data)+need)
+	if r.maxCap > 0 {
+		newCap = min(newCap, r.maxCap)
+	}
+
+	if newCap <= len(r.data) {
+		return false
+	}
+
+	r.resize(newCap)
+	return true
+}
+
+// copies elems (no more than the capacity) after the last element,
+// wrapping around at most once, numbers them and moves the end forward.
+// The caller accounts for the new size.
+func (r *RingQueue[T]) write(elems []T) {
+	n := copy(r.data[r.end:], elems)
+	copy(r.data, elems[n:])
+	for range elems {
+		r.seq++
+		r.seqs[r.end] = r.seq
+		r.end = (r.end + 1) % len(r.data)
+	}
+}
+
+// the sequence number of the element at logical position i
+func (r *RingQueue[T]) seqAt(i int) uint64 {
+	return r.seqs[(r.start+i)%len(r.seqs)]
+}
+
+// the logical position of the first element numbered seq or later,
+// the size if there is none. The numbers grow in FIFO order, though
+// not one by one where PopBack() took some back.
+func (r *RingQueue[T]) seqIndex(seq uint64) int {
+	return sort.Search(r.Size(), func(i int) bool {
+		return r.seqAt(i) >= seq
+	})
+}
+
+// copies the first len(dst) elements (no more than the size) into dst,
+// wrapping around at most once. Neither start nor size are modified.
+func (r *RingQueue[T]) read(dst []T) {
+	if len(dst) == 0 {
+		return // data may be nil once closed
+	}
+
+	n := copy(dst, r.data[r.start:])
+	copy(dst[n:], r.data)
+}
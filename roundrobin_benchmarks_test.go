@@ -14,6 +14,7 @@ package roundrobin
 */
 
 import (
+	"runtime"
 	"testing"
 )
 
@@ -52,7 +53,24 @@ func BenchmarkRuneRingQueue(b *testing.B) {
 }
 
 /**
- * Benchmarking plain GENERIC RingQueue[int]
+ * Benchmarking the byte-specialized ByteRingQueue through
+ * its io.Writer/io.Reader methods, a chunk at a time.
+ */
+func BenchmarkByteRingQueue(b *testing.B) {
+	rr := NewByteRingQueue(1_000)
+	chunk := make([]byte, 64)
+
+	b.SetBytes(int64(len(chunk)))
+	for b.Loop() {
+		if _, err := rr.Write(chunk); err != nil {
+			rr.Read(chunk)
+		}
+	}
+}
+
+/**
+ * Benchmarking plain GENERIC RingQueue[int]. Run it again with
+ * -tags nostats to weigh the overhead of the statistics.
  */
 func BenchmarkRingQueue(b *testing.B) {
 	rr := NewRingQueue[int](1_000)
@@ -82,3 +100,70 @@ func BenchmarkArray(b *testing.B) {
 		size++
 	}
 }
+
+/**
+ * One producer and one consumer Go routine passing elements through
+ * the mutex-based thread-safe queue.
+ */
+func BenchmarkSafeRingQueueSPSC(b *testing.B) {
+	benchmarkSPSC(b, NewSafeRingQueue[int](1_024, WhenFullError, WhenEmptyError, nil))
+}
+
+/**
+ * Same as above through the lock-free SPSCRingQueue.
+ */
+func BenchmarkSPSCRingQueue(b *testing.B) {
+	benchmarkSPSC(b, NewSPSCRingQueue[int](1_024))
+}
+
+/**
+ * GOMAXPROCS Go routines pushing and popping concurrently through
+ * the mutex-based thread-safe queue.
+ */
+func BenchmarkSafeRingQueueMPMC(b *testing.B) {
+	benchmarkMPMC(b, NewSafeRingQueue[int](1_024, WhenFullError, WhenEmptyError, nil))
+}
+
+/**
+ * Same as above through the lock-free MPMCRingQueue.
+ */
+func BenchmarkMPMCRingQueue(b *testing.B) {
+	benchmarkMPMC(b, NewMPMCRingQueue[int](1_024, WhenFullError, WhenEmptyError, nil))
+}
+
+/* ----------------------------------------------------------------
+ *					F u n c t i o n s
+ *-----------------------------------------------------------------*/
+
+// a producer Go routine pushes b.N elements which the benchmark pops,
+// both sides yielding whenever the queue is full or empty.
+func benchmarkSPSC(b *testing.B, rr IRingQueue[int]) {
+	go func() {
+		for n := 0; n < b.N; {
+			if _, err := rr.Push(n); err != nil {
+				runtime.Gosched()
+				continue
+			}
+			n++
+		}
+	}()
+
+	for n := 0; n < b.N; {
+		if _, _, err := rr.Pop(); err != nil {
+			runtime.Gosched()
+			continue
+		}
+		n++
+	}
+}
+
+// every Go routine alternates pushes and pops, contending with the
+// others on both ends of the queue.
+func benchmarkMPMC(b *testing.B, rr IRingQueue[int]) {
+	b.RunParallel(func(pb *testing.PB) {
+		for n := 0; pb.Next(); n++ {
+			rr.Push(n)
+			rr.Pop()
+		}
+	})
+}
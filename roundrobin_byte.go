@@ -0,0 +1,828 @@
+/* -----------------------------------------------------------------
+ *				   P u b l i c   D o m a i n / F O S
+ *				Copyright (C)2025 Dídimo Grimaldo T.
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * A byte-specific version that does not have the overhead of generics
+ * and streams through the standard io interfaces.
+ *-----------------------------------------------------------------*/
+package roundrobin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+/* ----------------------------------------------------------------
+ *				I n t e r f a c e s
+ *-----------------------------------------------------------------*/
+
+var _ IRingQueue[byte] = (*ByteRingQueue)(nil)
+var _ ContextQueue[byte] = (*ByteRingQueue)(nil)
+var _ io.ReadWriter = (*ByteRingQueue)(nil)
+var _ io.ByteReader = (*ByteRingQueue)(nil)
+var _ io.ByteWriter = (*ByteRingQueue)(nil)
+var _ io.ReaderFrom = (*ByteRingQueue)(nil)
+var _ io.WriterTo = (*ByteRingQueue)(nil)
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		T y p e s
+ *-----------------------------------------------------------------*/
+
+/**
+ * A specialized version of the generics RingQueue[T] for bytes, the
+ * RuneRingQueue having shown that a concrete type beats generics.
+ * Besides the IRingQueue[byte] methods it is an io.Reader/io.Writer,
+ * moving the bytes with slice copies across the wrap: reading an empty
+ * queue returns io.EOF and writing onto a full one a short write.
+ */
+type ByteRingQueue struct {
+	data  []byte // container data of bytes
+	count *SafeCounter
+	start int // start index (inclusive, i.e. first element)
+	end   int // end index (exclusive, i.e. next after last element)
+
+	whenFull   WhenFull
+	closed     bool
+	onClose    OnCloseCallback[byte]
+	onEvict    OnEvictCallback[byte]
+	fullPolicy FullPolicy[byte]
+	closeOnce  sync.Once
+	hooks      Hooks[byte]
+
+	// WhenFullGrow
+	baseCap    int     // capacity Shrink() returns to
+	growFactor float64 // capacity multiplier on each growth
+	maxCap     int     // hard limit of the growth, zero for none
+}
+
+/* ----------------------------------------------------------------
+ *				C o n s t r u c t o r s
+ *-----------------------------------------------------------------*/
+
+/**
+ * A specific (non-generic) Ring Queue to hold bytes.
+ */
+func NewByteRingQueue(capacity int) *ByteRingQueue {
+	return &ByteRingQueue{
+		data:     make([]byte, capacity),
+		count:    NewSafeCounter(),
+		start:    0,
+		end:      0,
+		whenFull: WhenFullError,
+
+		baseCap:    capacity,
+		growFactor: 2,
+	}
+}
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		M e t h o d s
+ *-----------------------------------------------------------------*/
+
+/**
+ * Configures WhenFullGrow: a full queue multiplies its capacity by
+ * factor, never beyond maxCap (zero means no limit).
+ */
+func (r *ByteRingQueue) SetGrowth(factor float64, maxCap int) error {
+	if factor <= 1 {
+		return ErrBadGrowth
+	}
+
+	r.growFactor = factor
+	r.maxCap = max(maxCap, 0)
+	return nil
+}
+
+/**
+ * Changes the capacity keeping the bytes in FIFO order, and makes it
+ * the base capacity Shrink() returns to. If the bytes do not fit,
+ * WhenFullOverwrite evicts the oldest ones, otherwise the queue is
+ * left untouched and ErrFullQueue is returned.
+ */
+func (r *ByteRingQueue) Resize(newCap int) error {
+	if r.closed {
+		return ErrClosed
+	}
+
+	if newCap <= 0 {
+		return ErrBadCapacity
+	}
+
+	if err := r.resize(newCap); err != nil {
+		return err
+	}
+
+	r.baseCap = newCap
+	return nil
+}
+
+/**
+ * Gives back the memory taken by WhenFullGrow, shrinking toward the
+ * base capacity but never below the current size.
+ */
+func (r *ByteRingQueue) Shrink() error {
+	if r.closed {
+		return ErrClosed
+	}
+
+	if newCap := max(r.baseCap, r.Size()); newCap < len(r.data) {
+		return r.resize(newCap)
+	}
+
+	return nil
+}
+
+func (r *ByteRingQueue) Reset() {
+	discarded := r.Size()
+	r.start = 0
+	r.end = 0
+	r.count.Clear()
+	clear(r.data)
+	r.hooks.reset(discarded)
+}
+
+// @implements fmt.Stringer
+func (r *ByteRingQueue) String() string {
+	return fmt.Sprintf(
+		"[ByteRQ full:%v size:%d start:%d end:%d data:%v]",
+		r.IsFull(),
+		len(r.data),
+		r.start,
+		r.end,
+		r.data)
+}
+
+func (r *ByteRingQueue) Push(elem byte) (int, error) {
+	newLen, _, _, err := r.pushEvict(elem)
+	return newLen, err
+}
+
+/**
+ * Like Push(), also reporting the byte the full queue discarded:
+ * the oldest one with WhenFullOverwrite or the incoming one with
+ * WhenFullDropNewest.
+ */
+func (r *ByteRingQueue) PushEvict(elem byte) (evicted byte, didEvict bool, err error) {
+	_, evicted, didEvict, err = r.pushEvict(elem)
+	return
+}
+
+/**
+ * Push() unless the context is already done. This queue never blocks,
+ * therefore the context is only checked once.
+ * @implement roundrobin.ContextQueue[byte]
+ */
+func (r *ByteRingQueue) PushCtx(ctx context.Context, elem byte) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return r.Push(elem)
+}
+
+/**
+ * Pushes a batch of bytes with at most two copy() calls. If the batch
+ * does not fit, WhenFullError writes what fits and returns ErrFullQueue,
+ * while WhenFullOverwrite keeps the newest bytes. WhenFullGrow grows
+ * once to fit the batch, up to its maximum. WhenFullDropNewest writes
+ * what fits without error, and with WhenFullCustom the policy decides
+ * for each byte that does not fit.
+ */
+func (r *ByteRingQueue) PushN(elems []byte) (written int, err error) {
+	if r.closed {
+		return 0, ErrClosed
+	}
+
+	free := len(r.data) - r.Size()
+	if len(elems) > free && r.whenFull == WhenFullGrow {
+		r.grow(len(elems) - free)
+		free = len(r.data) - r.Size()
+	}
+
+	if len(elems) > free {
+		switch r.whenFull {
+		case WhenFullError, WhenFullBlock, WhenFullGrow:
+			r.write(elems[:free])
+			r.count.Add(int64(free))
+			r.pushed(free)
+			return free, ErrFullQueue
+
+		case WhenFullDropNewest:
+			r.write(elems[:free])
+			r.count.Add(int64(free))
+			r.pushed(free)
+			r.evictAll(elems[free:])
+			return free, nil
+
+		case WhenFullCustom:
+			r.write(elems[:free])
+			r.count.Add(int64(free))
+			r.pushed(free)
+			for i, elem := range elems[free:] {
+				if _, err = r.Push(elem); err != nil {
+					return free + i, err
+				}
+			}
+			return len(elems), nil
+
+		case WhenFullOverwrite:
+			// only the last Cap() bytes survive anyway
+			if len(elems) > len(r.data) {
+				r.evict((r.end+free)%len(r.data), len(r.data)-free)
+				r.evictAll(elems[:len(elems)-len(r.data)])
+				r.write(elems[len(elems)-len(r.data):])
+			} else {
+				r.evict((r.end+free)%len(r.data), len(elems)-free)
+				r.write(elems)
+			}
+			r.start = r.end // full, so end wrapped onto start
+			r.count.Add(int64(free))
+			r.pushed(min(len(elems), len(r.data)))
+			return len(elems), nil
+
+		default:
+			return 0, errors.ErrUnsupported
+		}
+	}
+
+	r.write(elems)
+	r.count.Add(int64(len(elems)))
+	r.pushed(len(elems))
+
+	return len(elems), nil
+}
+
+func (r *ByteRingQueue) Pop() (byte, int, error) {
+	var res byte = 0
+	if r.closed {
+		return res, 0, ErrClosed
+	}
+
+	if r.count.Value() == 0 {
+		return res, 0, ErrEmptyQueue
+	}
+
+	res = r.data[r.start]                 // copy over the first element in the queue
+	r.start = (r.start + 1) % len(r.data) // move the start of the queue
+	newLen := r.count.Decrement()
+	r.popped(1)
+
+	return res, int(newLen), nil
+}
+
+/**
+ * Pop() unless the context is already done. This queue never blocks,
+ * therefore the context is only checked once.
+ * @implement roundrobin.ContextQueue[byte]
+ */
+func (r *ByteRingQueue) PopCtx(ctx context.Context) (byte, int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, 0, err
+	}
+
+	return r.Pop()
+}
+
+/**
+ * Pops up to len(dst) bytes into dst with at most two copy() calls.
+ * It returns ErrEmptyQueue only when there was nothing to pop.
+ */
+func (r *ByteRingQueue) PopN(dst []byte) (n int, err error) {
+	if r.closed {
+		return 0, ErrClosed
+	}
+
+	if len(dst) == 0 {
+		return 0, nil
+	}
+
+	if r.count.IsZero() {
+		return 0, ErrEmptyQueue
+	}
+
+	n = min(len(dst), r.Size())
+	r.read(dst[:n])
+	r.start = (r.start + n) % len(r.data)
+	r.count.Add(int64(-n))
+	r.popped(n)
+
+	return n, nil
+}
+
+func (r *ByteRingQueue) Peek() (byte, int, error) {
+	var res byte = 0
+	if r.closed {
+		return res, 0, ErrClosed
+	}
+
+	if r.count.IsZero() {
+		return res, 0, ErrEmptyQueue
+	}
+
+	return r.data[r.start], int(r.count.Value()), nil
+}
+
+/**
+ * Peeks the newest byte, i.e. the one a Push() just added.
+ */
+func (r *ByteRingQueue) Back() (byte, int, error) {
+	if r.closed {
+		return 0, 0, ErrClosed
+	}
+
+	if r.count.IsZero() {
+		return 0, 0, ErrEmptyQueue
+	}
+
+	return r.data[(r.end-1+len(r.data))%len(r.data)], int(r.count.Value()), nil
+}
+
+/**
+ * The byte at logical position i without popping it. Zero is the
+ * oldest byte, negative positions count from the newest (-1).
+ */
+func (r *ByteRingQueue) At(i int) (byte, error) {
+	if r.closed {
+		return 0, ErrClosed
+	}
+
+	size := r.Size()
+	if i < 0 {
+		i += size
+	}
+	if i < 0 || i >= size {
+		return 0, ErrOutOfRange
+	}
+
+	return r.data[(r.start+i)%len(r.data)], nil
+}
+
+/**
+ * A copy of the bytes in FIFO order.
+ */
+func (r *ByteRingQueue) ToSlice() []byte {
+	return r.AppendTo(nil)
+}
+
+/**
+ * Appends the bytes in FIFO order to dst, growing it if needed.
+ */
+func (r *ByteRingQueue) AppendTo(dst []byte) []byte {
+	n, size := len(dst), r.Size()
+	dst = slices.Grow(dst, size)[:n+size]
+	r.read(dst[n:])
+
+	return dst
+}
+
+func (r *ByteRingQueue) Size() int {
+	if r.closed {
+		return 0
+	}
+
+	return int(r.count.Value())
+}
+
+func (r *ByteRingQueue) Cap() int {
+	if r.closed {
+		return 0
+	}
+
+	return len(r.data)
+}
+
+func (r *ByteRingQueue) IsFull() bool {
+	if r.closed {
+		return false
+	}
+
+	// since Ctor(capacity) is an int, this cast will never go wrong
+	// @note unless the Ctor capacity type is changed to int64
+	return len(r.data) == int(r.count.Value())
+}
+
+/**
+ * Sets the behaviour when pushing onto a full Ring Queue.
+ * It can throw an error, overwrite old data, drop new data, grow the
+ * capacity or let a user-defined policy decide.
+ */
+func (r *ByteRingQueue) SetWhenFull(a WhenFull) IRingQueue[byte] {
+	r.whenFull = a
+	return r
+}
+
+/**
+ * Sets the callback receiving each byte a full queue discards: the
+ * oldest ones WhenFullOverwrite, including those evicted by Resize()
+ * and dropped by a PushN() larger than the capacity, and the incoming
+ * ones WhenFullDropNewest.
+ */
+func (r *ByteRingQueue) SetOnEvict(callback OnEvictCallback[byte]) IRingQueue[byte] {
+	r.onEvict = callback
+	return r
+}
+
+/**
+ * Sets the policy deciding, for each byte pushed onto a full queue,
+ * what WhenFullCustom does. Without a policy it fails with ErrFullQueue.
+ */
+func (r *ByteRingQueue) SetFullPolicy(policy FullPolicy[byte]) IRingQueue[byte] {
+	r.fullPolicy = policy
+	return r
+}
+
+/**
+ * Throws ErrUnsupported. Simply complies with the interface.
+ * @implement roundrobin.IRingQueue[byte]
+ */
+func (r *ByteRingQueue) SetPopDeadline(t time.Time) error {
+	return errors.ErrUnsupported
+}
+
+/**
+ * Sets the callback receiving, on Close(), each byte still queued.
+ */
+func (r *ByteRingQueue) SetOnClose(callback OnCloseCallback[byte]) IRingQueue[byte] {
+	r.onClose = callback
+	return r
+}
+
+/**
+ * Replaces the hooks installed so far, OnClose included. Like the
+ * other methods, Read(), Write(), ReadFrom() and WriteTo() call
+ * OnPush and OnPop once per byte.
+ */
+func (r *ByteRingQueue) SetHooks(hooks Hooks[byte]) IRingQueue[byte] {
+	r.hooks = hooks
+	return r.SetOnClose(hooks.OnClose)
+}
+
+/**
+ * Hands the remaining bytes over to the OnClose callback in FIFO
+ * order and releases the data. Any further operation on the queue
+ * throws ErrClosed, closing it again does nothing.
+ * @implement io.Closer
+ */
+func (r *ByteRingQueue) Close() error {
+	r.closeOnce.Do(func() {
+		if r.onClose != nil {
+			for range r.Size() {
+				res := r.data[r.start]
+				r.start = (r.start + 1) % len(r.data)
+				r.onClose(res)
+			}
+		}
+		r.closed = true
+		r.count.Clear()
+		r.data = nil
+	})
+	return nil
+}
+
+/**
+ * Pops up to len(p) bytes into p. An empty queue reads as io.EOF,
+ * though later Write() calls may bring more bytes to read.
+ * @implement io.Reader
+ */
+func (r *ByteRingQueue) Read(p []byte) (n int, err error) {
+	n, err = r.PopN(p)
+	if err == ErrEmptyQueue {
+		err = io.EOF
+	}
+
+	return
+}
+
+/**
+ * Pushes p like PushN(), a batch that does not fit being a short
+ * write: with ErrFullQueue for WhenFullError, WhenFullBlock and
+ * WhenFullGrow at its maximum, or with io.ErrShortWrite when
+ * WhenFullDropNewest drops the bytes that did not fit.
+ * @implement io.Writer
+ */
+func (r *ByteRingQueue) Write(p []byte) (n int, err error) {
+	n, err = r.PushN(p)
+	if err == nil && n < len(p) {
+		err = io.ErrShortWrite
+	}
+
+	return
+}
+
+/**
+ * Pop() returning io.EOF on an empty queue.
+ * @implement io.ByteReader
+ */
+func (r *ByteRingQueue) ReadByte() (byte, error) {
+	c, _, err := r.Pop()
+	if err == ErrEmptyQueue {
+		err = io.EOF
+	}
+
+	return c, err
+}
+
+/**
+ * Push() under the WhenFull policy.
+ * @implement io.ByteWriter
+ */
+func (r *ByteRingQueue) WriteByte(c byte) error {
+	_, err := r.Push(c)
+	return err
+}
+
+/**
+ * Reads from src until io.EOF straight into the free slots. A full
+ * queue grows with WhenFullGrow, otherwise WhenFullError and
+ * WhenFullBlock stop with ErrFullQueue, leaving the rest in src.
+ * The other policies keep reading and decide which bytes are kept,
+ * hence with an endless src it never returns.
+ * @implement io.ReaderFrom
+ */
+func (r *ByteRingQueue) ReadFrom(src io.Reader) (n int64, err error) {
+	if r.closed {
+		return 0, ErrClosed
+	}
+
+	var chunk []byte // only needed once full
+	for {
+		var m int
+		if r.IsFull() && !(r.whenFull == WhenFullGrow && r.grow(1)) {
+			switch r.whenFull {
+			case WhenFullError, WhenFullBlock, WhenFullGrow:
+				return n, ErrFullQueue
+			}
+
+			// PushN() applies the policy to what comes next
+			if chunk == nil {
+				chunk = make([]byte, len(r.data))
+			}
+			m, err = src.Read(chunk)
+			if _, perr := r.PushN(chunk[:m]); perr != nil && err == nil {
+				err = perr
+			}
+		} else {
+			m, err = src.Read(r.freeSlots())
+			r.end = (r.end + m) % len(r.data)
+			r.count.Add(int64(m))
+			r.pushed(m)
+		}
+
+		n += int64(m)
+		if err == io.EOF {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+	}
+}
+
+/**
+ * Drains the queue into w, handing it the bytes up to the wrap and
+ * then the rest without any intermediate copy. Whatever w does not
+ * accept remains queued.
+ * @implement io.WriterTo
+ */
+func (r *ByteRingQueue) WriteTo(w io.Writer) (n int64, err error) {
+	if r.closed {
+		return 0, ErrClosed
+	}
+
+	for !r.count.IsZero() {
+		chunk := r.data[r.start:min(r.start+r.Size(), len(r.data))]
+		m, err := w.Write(chunk)
+		r.start = (r.start + m) % len(r.data)
+		r.count.Add(int64(-m))
+		r.popped(m)
+		n += int64(m)
+
+		if err != nil {
+			return n, err
+		}
+		if m < len(chunk) {
+			return n, io.ErrShortWrite
+		}
+	}
+
+	return n, nil
+}
+
+/* ----------------------------------------------------------------
+ *				P r i v a t e	M e t h o d s
+ *-----------------------------------------------------------------*/
+
+/**
+ * Push() also reporting what a full queue discarded, be it the oldest
+ * byte (WhenFullOverwrite) or the incoming one (WhenFullDropNewest).
+ */
+func (r *ByteRingQueue) pushEvict(elem byte) (newLen int, evicted byte, didEvict bool, err error) {
+	if r.closed {
+		return 0, 0, false, ErrClosed
+	}
+
+	noIncrement := false
+
+	if r.IsFull() {
+		switch r.policyFor(elem) {
+		case WhenFullError, WhenFullBlock:
+			// a plain queue cannot block, the thread-safe
+			// wrapper waits on ErrFullQueue when blocking.
+			return r.Size(), 0, false, ErrFullQueue
+
+		case WhenFullDropNewest:
+			// the OLDEST data is prioritized, the
+			// incoming byte is dropped silently.
+			r.evictAll([]byte{elem})
+			return r.Size(), elem, true, nil
+
+		case WhenFullOverwrite:
+			// continue pushing with loss of data
+			// the OLDEST data gets overwritten as
+			// fresher data is prioritized.
+			noIncrement = true
+			newLen = len(r.data)
+			evicted, didEvict = r.data[r.end], true
+			r.evict(r.end, 1)
+
+		case WhenFullGrow:
+			if !r.grow(1) {
+				return r.Size(), 0, false, ErrFullQueue
+			}
+
+		default:
+			return len(r.data), 0, false, errors.ErrUnsupported
+		}
+	}
+
+	r.data[r.end] = elem              // place the new element on the available space
+	r.end = (r.end + 1) % len(r.data) // move the end forward by modulo of capacity
+	if noIncrement {
+		r.start = r.end // the oldest was overwritten, the next one is now first
+	} else {
+		newLen = int(r.count.Increment())
+	}
+	r.pushed(1)
+
+	return newLen, evicted, didEvict, nil
+}
+
+/**
+ * The WhenFull applying to a byte pushed onto a full queue, which
+ * with WhenFullCustom is up to the user-defined policy.
+ */
+func (r *ByteRingQueue) policyFor(elem byte) WhenFull {
+	if r.whenFull != WhenFullCustom {
+		return r.whenFull
+	}
+
+	if r.fullPolicy == nil {
+		return WhenFullError
+	}
+
+	switch r.fullPolicy(r, elem) {
+	case DecisionDrop:
+		return WhenFullDropNewest
+	case DecisionOverwrite:
+		return WhenFullOverwrite
+	default:
+		return WhenFullError
+	}
+}
+
+/**
+ * Reallocates the data with newCap slots, evicting the oldest bytes
+ * if WhenFullOverwrite and they do not fit. The bytes are moved to
+ * the beginning of the new data.
+ */
+func (r *ByteRingQueue) resize(newCap int) error {
+	size := r.Size()
+	if newCap < size {
+		if r.whenFull != WhenFullOverwrite {
+			return ErrFullQueue
+		}
+
+		r.evict(r.start, size-newCap)
+		r.start = (r.start + size - newCap) % len(r.data)
+		r.count.Add(int64(newCap - size))
+		size = newCap
+	}
+
+	data := make([]byte, newCap)
+	r.read(data[:size])
+	r.data = data
+	r.start = 0
+	r.end = size % newCap
+
+	return nil
+}
+
+/**
+ * Hands the n bytes just pushed, right before the end, over to
+ * the OnPush hook.
+ */
+func (r *ByteRingQueue) pushed(n int) {
+	for i := range n {
+		r.hooks.push(r.data[(r.end-n+i+len(r.data))%len(r.data)])
+	}
+}
+
+/**
+ * Hands the n bytes just popped, right before the start, over to
+ * the OnPop hook. Their slots still hold them.
+ */
+func (r *ByteRingQueue) popped(n int) {
+	for i := range n {
+		r.hooks.pop(r.data[(r.start-n+i+len(r.data))%len(r.data)])
+	}
+}
+
+/**
+ * Hands n bytes from position pos onward over to the eviction
+ * callback, before they get overwritten.
+ */
+func (r *ByteRingQueue) evict(pos, n int) {
+	for i := range n {
+		evicted := r.data[(pos+i)%len(r.data)]
+		r.hooks.overwrite(evicted)
+		if r.onEvict != nil {
+			r.onEvict(evicted)
+		}
+	}
+}
+
+/**
+ * Hands incoming bytes that never made it into the queue over to
+ * the eviction callback.
+ */
+func (r *ByteRingQueue) evictAll(elems []byte) {
+	if r.onEvict == nil {
+		return
+	}
+
+	for _, elem := range elems {
+		r.onEvict(elem)
+	}
+}
+
+/**
+ * Grows the capacity by the growth factor, or more if that does not
+ * make room for need bytes, without exceeding the maximum.
+ * It returns false if the capacity could not grow at all.
+ */
+func (r *ByteRingQueue) grow(need int) bool {
+	newCap := int(math.Ceil(float64(len(r.data)) * r.growFactor))
+	newCap = max(newCap, len(r.data)+need)
+	if r.maxCap > 0 {
+		newCap = min(newCap, r.maxCap)
+	}
+
+	if newCap <= len(r.data) {
+		return false
+	}
+
+	r.resize(newCap)
+	return true
+}
+
+/**
+ * Copies bytes (no more than the capacity) after the last one,
+ * wrapping around at most once, and moves the end forward.
+ * The caller accounts for the new size.
+ */
+func (r *ByteRingQueue) write(elems []byte) {
+	n := copy(r.data[r.end:], elems)
+	copy(r.data, elems[n:])
+	r.end = (r.end + len(elems)) % len(r.data)
+}
+
+/**
+ * Copies the first len(dst) bytes (no more than the size) into dst,
+ * wrapping around at most once. Neither start nor size are modified.
+ */
+func (r *ByteRingQueue) read(dst []byte) {
+	if len(dst) == 0 {
+		return // data may be nil once closed
+	}
+
+	n := copy(dst, r.data[r.start:])
+	copy(dst[n:], r.data)
+}
+
+/**
+ * The free slots after the last byte, up to the wrap or the first
+ * byte. The queue must not be full.
+ */
+func (r *ByteRingQueue) freeSlots() []byte {
+	if r.end < r.start {
+		return r.data[r.end:r.start]
+	}
+
+	return r.data[r.end:]
+}
@@ -0,0 +1,118 @@
+/* -----------------------------------------------------------------
+ *				   P u b l i c   D o m a i n / F O S
+ *				Copyright (C)2025 Dídimo Grimaldo T.
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * Tests for the io interfaces of the byte-specific ByteRingQueue
+ *-----------------------------------------------------------------*/
+package roundrobin
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+/* ----------------------------------------------------------------
+ *						T e s t s
+ *-----------------------------------------------------------------*/
+
+func Test_ByteReadWrite(t *testing.T) {
+	obj := NewByteRingQueue(8)
+	obj.Write([]byte("hello"))
+	obj.Read(make([]byte, 3))
+
+	if n, err := obj.Write([]byte("world!")); n != 6 || err != nil {
+		t.Fatalf("expected 6 & nil, got %d & %v", n, err)
+	}
+	if n, err := obj.Write([]byte("xyz")); n != 0 || err != ErrFullQueue {
+		t.Fatalf("expected a short write with ErrFullQueue, got %d & %v", n, err)
+	}
+
+	p := make([]byte, 16)
+	if n, err := obj.Read(p); string(p[:n]) != "loworld!" || err != nil {
+		t.Fatalf("expected \"loworld!\" across the wrap, got %q & %v", p[:n], err)
+	}
+	if n, err := obj.Read(p); n != 0 || err != io.EOF {
+		t.Fatalf("expected io.EOF when empty, got %d & %v", n, err)
+	}
+
+	obj.WriteByte('a')
+	if c, err := obj.ReadByte(); c != 'a' || err != nil {
+		t.Fatalf("expected 'a' & nil, got %q & %v", c, err)
+	}
+	if _, err := obj.ReadByte(); err != io.EOF {
+		t.Fatalf("expected io.EOF when empty, got %v", err)
+	}
+
+	obj.SetWhenFull(WhenFullDropNewest)
+	if n, err := obj.Write([]byte("0123456789")); n != 8 || err != io.ErrShortWrite {
+		t.Fatalf("expected 8 & io.ErrShortWrite, got %d & %v", n, err)
+	}
+
+	if err := iotest.TestReader(obj, []byte("01234567")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_ByteCopy(t *testing.T) {
+	text := strings.Repeat("The quick brown fox jumps over the lazy dog. ", 100)
+
+	// grows to take the whole stream and hands it over unchanged
+	obj := NewByteRingQueue(16)
+	obj.SetWhenFull(WhenFullGrow)
+	obj.Write([]byte("#"))
+	obj.ReadByte() // not starting at the beginning of the data
+	if n, err := io.Copy(obj, strings.NewReader(text)); n != int64(len(text)) || err != nil {
+		t.Fatalf("expected %d & nil, got %d & %v", len(text), n, err)
+	}
+	var buf bytes.Buffer
+	if n, err := io.Copy(&buf, obj); n != int64(len(text)) || err != nil || buf.String() != text {
+		t.Fatalf("expected the text back, got %d & %v", n, err)
+	}
+
+	// stops once full, the rest remains in the source
+	obj = NewByteRingQueue(16)
+	src := strings.NewReader(text)
+	if n, err := obj.ReadFrom(src); n != 16 || err != ErrFullQueue {
+		t.Fatalf("expected 16 & ErrFullQueue, got %d & %v", n, err)
+	}
+	if src.Len() != len(text)-16 {
+		t.Fatalf("expected %d bytes left in the source, got %d", len(text)-16, src.Len())
+	}
+
+	// keeps only the tail of the stream
+	obj = NewByteRingQueue(16)
+	obj.SetWhenFull(WhenFullOverwrite)
+	if n, err := obj.ReadFrom(iotest.OneByteReader(strings.NewReader(text))); n != int64(len(text)) || err != nil {
+		t.Fatalf("expected %d & nil, got %d & %v", len(text), n, err)
+	}
+	if got := string(obj.ToSlice()); got != text[len(text)-16:] {
+		t.Fatalf("expected the last 16 bytes, got %q", got)
+	}
+
+	// a writer taking less leaves the rest queued
+	buf.Reset()
+	if n, err := obj.WriteTo(iotest.TruncateWriter(&buf, 5)); n != 16 || err != nil {
+		t.Fatalf("expected 16 & nil, got %d & %v", n, err)
+	}
+	obj.Write([]byte("abc"))
+	if n, err := obj.WriteTo(&limitedWriter{&buf, 2}); n != 2 || err != io.ErrShortWrite || obj.Size() != 1 {
+		t.Fatalf("expected 2 & io.ErrShortWrite with 1 byte queued, got %d & %v & %d", n, err, obj.Size())
+	}
+}
+
+/* ----------------------------------------------------------------
+ *					F u n c t i o n s
+ *-----------------------------------------------------------------*/
+
+// accepts only the first n bytes of each Write() without error
+type limitedWriter struct {
+	w io.Writer
+	n int
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	return l.w.Write(p[:min(len(p), l.n)])
+}
@@ -0,0 +1,493 @@
+/* -----------------------------------------------------------------
+ *				   P u b l i c   D o m a i n / F O S
+ *				Copyright (C)2025 Dídimo Grimaldo T.
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * A lock-free bounded queue for many producers and many consumers
+ * after Dmitry Vyukov's design: every slot carries a sequence number
+ * telling whether it is ready to be pushed onto or popped from, so
+ * that each side only has to win a compare-and-swap on its index.
+ * Blocking on an empty or full queue is layered on top of it.
+ *-----------------------------------------------------------------*/
+package roundrobin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/bits"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pion/transport/v3/deadline"
+)
+
+/* ----------------------------------------------------------------
+ *				I n t e r f a c e s
+ *-----------------------------------------------------------------*/
+
+var _ IRingQueue[int] = (*MPMCRingQueue[int])(nil)
+var _ ContextQueue[int] = (*MPMCRingQueue[int])(nil)
+var _ PushDeadlineQueue[int] = (*MPMCRingQueue[int])(nil)
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		T y p e s
+ *-----------------------------------------------------------------*/
+
+/**
+ * A multi-producer/multi-consumer ring where neither Push nor Pop
+ * takes a lock unless it has to block. The capacity is a power of
+ * two of at least two. Blocked Go routines are woken up all at once
+ * and race for the element (or slot) rather than being served in
+ * FIFO order like in the mutex-based thread-safe queue.
+ */
+type MPMCRingQueue[T any] struct {
+	_ [cacheLineSize]byte
+
+	head atomic.Uint64 // next position to Pop()
+
+	_ [cacheLineSize - 8]byte
+
+	tail atomic.Uint64 // next position to Push()
+
+	_ [cacheLineSize - 8]byte
+
+	slots []mpmcSlot[T]
+	mask  uint64
+
+	whenFull  atomic.Int32
+	whenEmpty atomic.Int32
+	closed    atomic.Bool
+	onClose   OnCloseCallback[T]
+	onEvict   OnEvictCallback[T]
+	closeOnce sync.Once
+	hooks     Hooks[T]
+
+	deadline     *deadline.Deadline
+	pushDeadline *deadline.Deadline
+	notEmpty     wakeup // Pop() waiting for data
+	notFull      wakeup // Push() waiting for a free slot
+}
+
+/* ----------------------------------------------------------------
+ *				P r i v a t e	T y p e s
+ *-----------------------------------------------------------------*/
+
+// seq equals the position of the next Push() when the slot is free,
+// and that position plus one once it holds the pushed element.
+type mpmcSlot[T any] struct {
+	seq  atomic.Uint64
+	elem T
+}
+
+// wakes every parked Go routine at once. The mutex is only taken by
+// those parking and when there is somebody to wake up.
+type wakeup struct {
+	mutex   sync.Mutex
+	ch      chan struct{}
+	waiters atomic.Int32
+}
+
+/* ----------------------------------------------------------------
+ *				C o n s t r u c t o r s
+ *-----------------------------------------------------------------*/
+
+/**
+ * Supports WhenFullError, WhenFullOverwrite, WhenFullBlock and
+ * WhenFullDropNewest, otherwise it returns nil like the mutex-based
+ * NewSafeRingQueue().
+ */
+func NewMPMCRingQueue[T any](capacity int, whenFull WhenFull, whenEmpty WhenEmpty, onCloseFunc OnCloseCallback[T]) *MPMCRingQueue[T] {
+	if whenEmpty != WhenEmptyBlock && whenEmpty != WhenEmptyError {
+		return nil
+	}
+
+	switch whenFull {
+	case WhenFullError, WhenFullOverwrite, WhenFullBlock, WhenFullDropNewest:
+	default:
+		return nil
+	}
+
+	capacity = 1 << bits.Len(uint(max(capacity, 2)-1))
+	q := &MPMCRingQueue[T]{
+		slots:        make([]mpmcSlot[T], capacity),
+		mask:         uint64(capacity - 1),
+		onClose:      onCloseFunc,
+		deadline:     deadline.New(),
+		pushDeadline: deadline.New(),
+	}
+	for i := range q.slots {
+		q.slots[i].seq.Store(uint64(i))
+	}
+	q.whenFull.Store(int32(whenFull))
+	q.whenEmpty.Store(int32(whenEmpty))
+
+	return q
+}
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		M e t h o d s
+ *-----------------------------------------------------------------*/
+
+func (q *MPMCRingQueue[T]) SetPopDeadline(t time.Time) error {
+	if WhenEmpty(q.whenEmpty.Load()) != WhenEmptyBlock {
+		return ErrBadDeadline
+	}
+
+	q.deadline.Set(t)
+
+	return nil
+}
+
+func (q *MPMCRingQueue[T]) SetPushDeadline(t time.Time) error {
+	if WhenFull(q.whenFull.Load()) != WhenFullBlock {
+		return ErrBadPushDeadline
+	}
+
+	q.pushDeadline.Set(t)
+
+	return nil
+}
+
+/**
+ * Must be set before the queue is shared.
+ */
+func (q *MPMCRingQueue[T]) SetOnClose(callback OnCloseCallback[T]) IRingQueue[T] {
+	q.onClose = callback
+	return q
+}
+
+/**
+ * Must be set before the queue is shared. The callback receives the
+ * oldest elements WhenFullOverwrite evicts and the incoming ones
+ * WhenFullDropNewest drops, in whichever Go routine pushed them.
+ */
+func (q *MPMCRingQueue[T]) SetOnEvict(callback OnEvictCallback[T]) IRingQueue[T] {
+	q.onEvict = callback
+	return q
+}
+
+/**
+ * Must be set before the queue is shared. The hooks run in whichever
+ * Go routine pushed or popped, possibly several at once.
+ */
+func (q *MPMCRingQueue[T]) SetHooks(hooks Hooks[T]) IRingQueue[T] {
+	q.hooks = hooks
+	return q.SetOnClose(hooks.OnClose)
+}
+
+func (q *MPMCRingQueue[T]) SetWhenFull(a WhenFull) IRingQueue[T] {
+	q.whenFull.Store(int32(a))
+	// blocked producers retry under the new policy
+	q.notFull.broadcast()
+	return q
+}
+
+/**
+ * Pops everything, which is safe while the queue is in use.
+ */
+func (q *MPMCRingQueue[T]) Reset() {
+	discarded := 0
+	for {
+		if _, ok := q.tryPop(); !ok {
+			break
+		}
+		discarded++
+	}
+
+	q.notFull.broadcast()
+	q.hooks.reset(discarded)
+}
+
+/**
+ * The remaining elements are handed over to the OnClose callback,
+ * and every blocked Go routine is woken up with ErrClosed.
+ * @implement io.Closer
+ */
+func (q *MPMCRingQueue[T]) Close() error {
+	q.closeOnce.Do(func() {
+		q.closed.Store(true)
+		for {
+			elem, ok := q.tryPop()
+			if !ok {
+				break
+			}
+			if q.onClose != nil {
+				q.onClose(elem)
+			}
+		}
+
+		q.notEmpty.broadcast()
+		q.notFull.broadcast()
+	})
+	return nil
+}
+
+// @implement fmt.Stringer
+func (q *MPMCRingQueue[T]) String() string {
+	return fmt.Sprintf(
+		"[MPMC full:%t max:%d head:%d tail:%d]",
+		q.Size() == len(q.slots),
+		len(q.slots),
+		q.head.Load(),
+		q.tail.Load())
+}
+
+/**
+ * A snapshot, which may already be outdated on return.
+ */
+func (q *MPMCRingQueue[T]) Size() int {
+	if q.closed.Load() {
+		return 0
+	}
+
+	head := q.head.Load() // the tail loaded later is never behind it
+	return min(int(q.tail.Load()-head), len(q.slots))
+}
+
+func (q *MPMCRingQueue[T]) Cap() int {
+	if q.closed.Load() {
+		return 0
+	}
+
+	return len(q.slots)
+}
+
+func (q *MPMCRingQueue[T]) Push(element T) (newLen int, err error) {
+	return q.PushCtx(context.Background(), element)
+}
+
+/**
+ * Like Push(), also reporting the element the full queue discarded:
+ * an oldest one with WhenFullOverwrite or the incoming one with
+ * WhenFullDropNewest.
+ */
+func (q *MPMCRingQueue[T]) PushEvict(element T) (evicted T, didEvict bool, err error) {
+	_, evicted, didEvict, _, err = q.pushEvict(context.Background(), element)
+	return
+}
+
+/**
+ * Like Push() but a WhenFullBlock wait is abandoned with ctx.Err()
+ * when the context is done.
+ * @implement roundrobin.ContextQueue[T]
+ */
+func (q *MPMCRingQueue[T]) PushCtx(ctx context.Context, element T) (newLen int, err error) {
+	newLen, _, _, _, err = q.pushEvict(ctx, element)
+	return
+}
+
+func (q *MPMCRingQueue[T]) Pop() (elem T, newLen int, err error) {
+	return q.PopCtx(context.Background())
+}
+
+/**
+ * Like Pop() but a WhenEmptyBlock wait is abandoned with ctx.Err()
+ * when the context is done.
+ * @implement roundrobin.ContextQueue[T]
+ */
+func (q *MPMCRingQueue[T]) PopCtx(ctx context.Context) (elem T, newLen int, err error) {
+	if err = ctx.Err(); err != nil {
+		return elem, 0, err
+	}
+
+	var wake <-chan struct{}
+	for {
+		if q.closed.Load() {
+			return elem, 0, ErrClosed
+		}
+
+		if res, ok := q.tryPop(); ok {
+			q.hooks.pop(res)
+			q.notFull.broadcast()
+			return res, q.Size(), nil
+		}
+
+		switch WhenEmpty(q.whenEmpty.Load()) {
+		case WhenEmptyError:
+			return elem, 0, ErrEmptyQueue
+
+		case WhenEmptyBlock:
+			if wake == nil {
+				wake = q.notEmpty.register()
+				continue // retry once more, a Push() may have just happened
+			}
+
+			select {
+			case <-wake:
+				wake = nil
+			case <-q.deadline.Done():
+				return elem, 0, context.DeadlineExceeded
+			case <-ctx.Done():
+				return elem, 0, ctx.Err()
+			}
+
+		default:
+			panic("unreachable")
+		}
+	}
+}
+
+/**
+ * Throws ErrUnsupported: another consumer could pop the element
+ * while it is being read.
+ */
+func (q *MPMCRingQueue[T]) Peek() (elem T, len int, err error) {
+	return elem, q.Size(), errors.ErrUnsupported
+}
+
+/* ----------------------------------------------------------------
+ *				P r i v a t e	M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// PushCtx() also reporting what a full queue discarded, be it an
+// oldest element (WhenFullOverwrite) or the incoming one, which then
+// was dropped (WhenFullDropNewest).
+func (q *MPMCRingQueue[T]) pushEvict(ctx context.Context, element T) (newLen int, evicted T, didEvict, dropped bool, err error) {
+	if err = ctx.Err(); err != nil {
+		return 0, evicted, false, false, err
+	}
+
+	var wake <-chan struct{}
+	for {
+		if q.closed.Load() {
+			return 0, evicted, didEvict, false, ErrClosed
+		}
+
+		if q.tryPush(element) {
+			q.hooks.push(element)
+			q.notEmpty.broadcast()
+			return q.Size(), evicted, didEvict, false, nil
+		}
+
+		switch WhenFull(q.whenFull.Load()) {
+		case WhenFullError:
+			return q.Size(), evicted, false, false, ErrFullQueue
+
+		case WhenFullDropNewest:
+			q.evict(element)
+			return q.Size(), element, true, true, nil
+
+		case WhenFullOverwrite:
+			// make room by dropping the oldest, though another
+			// producer may take the slot before we retry.
+			if oldest, ok := q.tryPop(); ok {
+				evicted, didEvict = oldest, true
+				q.hooks.overwrite(oldest)
+				q.evict(oldest)
+			}
+
+		case WhenFullBlock:
+			if wake == nil {
+				wake = q.notFull.register()
+				continue // retry once more, a Pop() may have just freed a slot
+			}
+
+			select {
+			case <-wake:
+				wake = nil
+			case <-q.pushDeadline.Done():
+				return q.Size(), evicted, false, false, context.DeadlineExceeded
+			case <-ctx.Done():
+				return q.Size(), evicted, false, false, ctx.Err()
+			}
+
+		default:
+			return len(q.slots), evicted, false, false, errors.ErrUnsupported
+		}
+	}
+}
+
+// @implement roundrobin.dropReporter[T]
+func (q *MPMCRingQueue[T]) pushDrop(elem T) (dropped bool, err error) {
+	_, _, _, dropped, err = q.pushEvict(context.Background(), elem)
+	return
+}
+
+// hands an element the full queue discarded over to the eviction callback
+func (q *MPMCRingQueue[T]) evict(elem T) {
+	if q.onEvict != nil {
+		q.onEvict(elem)
+	}
+}
+
+// claims the slot at the tail, false if the queue is full.
+func (q *MPMCRingQueue[T]) tryPush(elem T) bool {
+	pos := q.tail.Load()
+	for {
+		slot := &q.slots[pos&q.mask]
+		switch seq := slot.seq.Load(); {
+		case seq == pos:
+			if q.tail.CompareAndSwap(pos, pos+1) {
+				slot.elem = elem
+				slot.seq.Store(pos + 1) // ready to be popped
+				return true
+			}
+			pos = q.tail.Load()
+
+		case seq < pos:
+			// not popped since the previous lap
+			return false
+
+		default:
+			// another producer got it first
+			pos = q.tail.Load()
+		}
+	}
+}
+
+// claims the slot at the head, false if the queue is empty.
+func (q *MPMCRingQueue[T]) tryPop() (elem T, ok bool) {
+	pos := q.head.Load()
+	for {
+		slot := &q.slots[pos&q.mask]
+		switch seq := slot.seq.Load(); {
+		case seq == pos+1:
+			if q.head.CompareAndSwap(pos, pos+1) {
+				var zero T
+				elem, slot.elem = slot.elem, zero
+				slot.seq.Store(pos + q.mask + 1) // free for the next lap
+				return elem, true
+			}
+			pos = q.head.Load()
+
+		case seq < pos+1:
+			// not pushed yet
+			return elem, false
+
+		default:
+			// another consumer got it first
+			pos = q.head.Load()
+		}
+	}
+}
+
+// the channel to park on. The caller must retry its operation once
+// after registering, or it might miss the wakeup it is waiting for.
+func (w *wakeup) register() <-chan struct{} {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.ch == nil {
+		w.ch = make(chan struct{})
+	}
+	w.waiters.Add(1)
+
+	return w.ch
+}
+
+// wakes everybody parked, if any.
+func (w *wakeup) broadcast() {
+	if w.waiters.Load() == 0 {
+		return
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.ch != nil {
+		close(w.ch)
+		w.ch = nil
+	}
+	w.waiters.Store(0)
+}
@@ -0,0 +1,143 @@
+/* -----------------------------------------------------------------
+ *				   P u b l i c   D o m a i n / F O S
+ *				Copyright (C)2025 Dídimo Grimaldo T.
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * Tests for the lock-free multi-producer/multi-consumer queue. Its
+ * stress tests are shared with the thread-safe queue.
+ *-----------------------------------------------------------------*/
+package roundrobin
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+/* ----------------------------------------------------------------
+ *						T e s t s
+ *-----------------------------------------------------------------*/
+
+func Test_MPMCPushPop(t *testing.T) {
+	if obj := NewMPMCRingQueue[int](4, WhenFullGrow, WhenEmptyError, nil); obj != nil {
+		t.Errorf("WhenFullGrow should not be supported")
+	}
+
+	obj := NewMPMCRingQueue[int](3, WhenFullError, WhenEmptyError, nil)
+	if obj.Cap() != 4 {
+		t.Fatalf("capacity should be rounded up to 4, got %d", obj.Cap())
+	}
+
+	for i := range 4 {
+		if newLen, err := obj.Push(i); newLen != i+1 || err != nil {
+			t.Fatalf("Push(%d) exp %d, nil got %d, %v", i, i+1, newLen, err)
+		}
+	}
+	if _, err := obj.Push(4); err != ErrFullQueue {
+		t.Errorf("Push on full should return ErrFullQueue, got %v", err)
+	}
+
+	// overwriting drops the oldest
+	obj.SetWhenFull(WhenFullOverwrite)
+	obj.Push(4)
+	obj.Push(5)
+	for exp := 2; exp <= 5; exp++ {
+		if v, _, err := obj.Pop(); v != exp || err != nil {
+			t.Errorf("Pop() exp %d, nil got %d, %v", exp, v, err)
+		}
+	}
+	if _, _, err := obj.Pop(); err != ErrEmptyQueue {
+		t.Errorf("Pop on empty should return ErrEmptyQueue, got %v", err)
+	}
+
+	var closed []int
+	obj.SetOnClose(func(v int) {
+		closed = append(closed, v)
+	})
+	obj.Push(6)
+	obj.Push(7)
+	obj.Close()
+	if expected := []int{6, 7}; !eqSlices(closed, expected) {
+		t.Errorf("onClose mismatch, expected:%v, found:%v", expected, closed)
+	}
+	if _, err := obj.Push(8); err != ErrClosed {
+		t.Errorf("Push on closed should return ErrClosed, got %v", err)
+	}
+}
+
+func Test_MPMCPushEvict(t *testing.T) {
+	var evicted []int
+	obj := NewMPMCRingQueue[int](2, WhenFullDropNewest, WhenEmptyError, nil)
+	obj.SetOnEvict(func(v int) {
+		evicted = append(evicted, v)
+	})
+
+	obj.Push(0)
+	obj.Push(1)
+	if v, didEvict, err := obj.PushEvict(2); v != 2 || !didEvict || err != nil {
+		t.Errorf("PushEvict dropped exp 2, true, nil got %d, %t, %v", v, didEvict, err)
+	}
+
+	obj.SetWhenFull(WhenFullOverwrite)
+	if v, didEvict, err := obj.PushEvict(3); v != 0 || !didEvict || err != nil {
+		t.Errorf("PushEvict overwriting exp 0, true, nil got %d, %t, %v", v, didEvict, err)
+	}
+	obj.Pop()
+	if _, didEvict, err := obj.PushEvict(4); didEvict || err != nil {
+		t.Errorf("PushEvict on non-full exp false, nil got %t, %v", didEvict, err)
+	}
+	if expected := []int{2, 0}; !eqSlices(evicted, expected) {
+		t.Errorf("evicted mismatch, expected:%v, found:%v", expected, evicted)
+	}
+}
+
+func Test_MPMCBlocking(t *testing.T) {
+	obj := NewMPMCRingQueue[int](2, WhenFullBlock, WhenEmptyBlock, nil)
+	obj.Push(0)
+	obj.Push(1)
+
+	pushed := make(chan error)
+	go func() {
+		_, err := obj.Push(2)
+		pushed <- err
+	}()
+
+	select {
+	case <-pushed:
+		t.Fatal("expected Push on full queue to block but it completed!")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	obj.Pop()
+	select {
+	case err := <-pushed:
+		if err != nil {
+			t.Errorf("blocked Push returned an error: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Push remained blocked after Pop freed a slot")
+	}
+
+	obj.SetPushDeadline(time.Now().Add(100 * time.Millisecond))
+	if _, err := obj.Push(3); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected DeadlineExceeded error, got %v", err)
+	}
+
+	obj.Reset()
+	popped := make(chan error)
+	go func() {
+		_, _, err := obj.Pop()
+		popped <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+	obj.Close()
+
+	select {
+	case err := <-popped:
+		if err != ErrClosed {
+			t.Errorf("Expected ErrClosed, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Pop remained blocked after Close")
+	}
+}
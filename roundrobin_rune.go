@@ -8,9 +8,15 @@
 package roundrobin
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sync"
 	"time"
+	"unicode/utf8"
 )
 
 /* ----------------------------------------------------------------
@@ -18,6 +24,11 @@ import (
  *-----------------------------------------------------------------*/
 
 var _ IRingQueue[rune] = (*RuneRingQueue)(nil)
+var _ ContextQueue[rune] = (*RuneRingQueue)(nil)
+var _ io.RuneScanner = (*RuneRingQueue)(nil)
+var _ io.StringWriter = (*RuneRingQueue)(nil)
+var _ StatsQueue = (*RuneRingQueue)(nil)
+var _ WatermarkQueue = (*RuneRingQueue)(nil)
 
 /* ----------------------------------------------------------------
  *				P u b l i c		T y p e s
@@ -35,7 +46,29 @@ type RuneRingQueue struct {
 	start int // start index (inclusive, i.e. first element)
 	end   int // end index (exclusive, i.e. next after last element)
 
-	whenFull WhenFull
+	whenFull   WhenFull
+	closed     bool
+	onClose    OnCloseCallback[rune]
+	onEvict    OnEvictCallback[rune]
+	fullPolicy FullPolicy[rune]
+	closeOnce  sync.Once
+
+	// UnreadRune()
+	lastRead  rune // the rune ReadRune() just popped
+	canUnread bool // nothing else was popped since
+
+	// Mark()
+	reads uint64   // runes popped from the head so far
+	marks []uint64 // reads at each uncommitted mark, oldest first
+
+	stats  statsBlock
+	levels watermarks
+	hooks  Hooks[rune]
+
+	// WhenFullGrow
+	baseCap    int     // capacity Shrink() returns to
+	growFactor float64 // capacity multiplier on each growth
+	maxCap     int     // hard limit of the growth, zero for none
 }
 
 /* ----------------------------------------------------------------
@@ -52,6 +85,9 @@ func NewRuneRingQueue(capacity int) *RuneRingQueue {
 		start:    0,
 		end:      0,
 		whenFull: WhenFullError,
+
+		baseCap:    capacity,
+		growFactor: 2,
 	}
 }
 
@@ -59,11 +95,69 @@ func NewRuneRingQueue(capacity int) *RuneRingQueue {
  *				P u b l i c		M e t h o d s
  *-----------------------------------------------------------------*/
 
+/**
+ * Configures WhenFullGrow: a full queue multiplies its capacity by
+ * factor, never beyond maxCap (zero means no limit).
+ */
+func (r *RuneRingQueue) SetGrowth(factor float64, maxCap int) error {
+	if factor <= 1 {
+		return ErrBadGrowth
+	}
+
+	r.growFactor = factor
+	r.maxCap = max(maxCap, 0)
+	return nil
+}
+
+/**
+ * Changes the capacity keeping the runes in FIFO order, and makes it
+ * the base capacity Shrink() returns to. If the runes do not fit,
+ * WhenFullOverwrite evicts the oldest ones, otherwise the queue is
+ * left untouched and ErrFullQueue is returned.
+ */
+func (r *RuneRingQueue) Resize(newCap int) error {
+	if r.closed {
+		return ErrClosed
+	}
+
+	if newCap <= 0 {
+		return ErrBadCapacity
+	}
+
+	if err := r.resize(newCap); err != nil {
+		return err
+	}
+
+	r.baseCap = newCap
+	return nil
+}
+
+/**
+ * Gives back the memory taken by WhenFullGrow, shrinking toward the
+ * base capacity but never below the current size.
+ */
+func (r *RuneRingQueue) Shrink() error {
+	if r.closed {
+		return ErrClosed
+	}
+
+	if newCap := max(r.baseCap, r.Size()+r.held()); newCap < len(r.data) {
+		return r.resize(newCap)
+	}
+
+	return nil
+}
+
 func (r *RuneRingQueue) Reset() {
+	discarded := r.Size()
+	r.canUnread = false
+	r.marks = nil
 	r.start = 0
 	r.end = 0
 	r.count.Clear()
 	clear(r.data)
+	r.hooks.reset(discarded)
+	r.levels.fell(0)
 }
 
 // @implements fmt.Stringer
@@ -78,50 +172,176 @@ func (r *RuneRingQueue) String() string {
 }
 
 func (r *RuneRingQueue) Push(elem rune) (int, error) {
-	noIncrement := false
-	var newLen int
+	newLen, _, _, err := r.pushEvict(elem)
+	return newLen, err
+}
 
-	if r.IsFull() {
+/**
+ * Like Push(), also reporting the rune the full queue discarded:
+ * the oldest one with WhenFullOverwrite or the incoming one with
+ * WhenFullDropNewest.
+ */
+func (r *RuneRingQueue) PushEvict(elem rune) (evicted rune, didEvict bool, err error) {
+	_, evicted, didEvict, err = r.pushEvict(elem)
+	return
+}
+
+/**
+ * Push() unless the context is already done. This queue never blocks,
+ * therefore the context is only checked once.
+ * @implement roundrobin.ContextQueue[rune]
+ */
+func (r *RuneRingQueue) PushCtx(ctx context.Context, elem rune) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return r.Push(elem)
+}
+
+/**
+ * Pushes a batch of runes with at most two copy() calls. If the batch
+ * does not fit, WhenFullError writes what fits and returns ErrFullQueue,
+ * while WhenFullOverwrite keeps the newest runes. WhenFullGrow grows
+ * once to fit the batch, up to its maximum. WhenFullDropNewest writes
+ * what fits without error, and with WhenFullCustom the policy decides
+ * for each rune that does not fit.
+ */
+func (r *RuneRingQueue) PushN(elems []rune) (written int, err error) {
+	if r.closed {
+		return 0, ErrClosed
+	}
+
+	free := len(r.data) - r.Size() - r.held()
+	if len(elems) > free && r.whenFull == WhenFullGrow {
+		r.grow(len(elems) - free)
+		free = len(r.data) - r.Size() - r.held()
+	}
+
+	if len(elems) > free {
 		switch r.whenFull {
-		case WhenFullError:
-			return r.Size(), ErrFullQueue
+		case WhenFullError, WhenFullBlock, WhenFullGrow:
+			r.write(elems[:free])
+			r.count.Add(int64(free))
+			r.pushed(free)
+			r.stats.rejected(len(elems) - free)
+			return free, ErrFullQueue
+
+		case WhenFullDropNewest:
+			r.write(elems[:free])
+			r.count.Add(int64(free))
+			r.pushed(free)
+			r.evictAll(elems[free:])
+			return free, nil
+
+		case WhenFullCustom:
+			return r.pushEach(elems, free)
 
 		case WhenFullOverwrite:
-			// continue pushing with loss of data
-			// the OLDEST data gets overwritten as
-			// fresher data is prioritized.
-			noIncrement = true
-			newLen = len(r.data)
+			if r.held() > 0 {
+				// Push() spares the runes held by a mark
+				return r.pushEach(elems, free)
+			}
+
+			// only the last Cap() runes survive anyway
+			if len(elems) > len(r.data) {
+				r.evict((r.end+free)%len(r.data), len(r.data)-free)
+				r.evictAll(elems[:len(elems)-len(r.data)])
+				r.write(elems[len(elems)-len(r.data):])
+			} else {
+				r.evict((r.end+free)%len(r.data), len(elems)-free)
+				r.write(elems)
+			}
+			r.start = r.end // full, so end wrapped onto start
+			r.count.Add(int64(free))
+			r.pushed(min(len(elems), len(r.data)))
+			return len(elems), nil
 
 		default:
-			return len(r.data), errors.ErrUnsupported
+			return 0, errors.ErrUnsupported
 		}
 	}
 
-	r.data[r.end] = elem              // place the new element on the available space
-	r.end = (r.end + 1) % len(r.data) // move the end forward by modulo of capacity
-	if !noIncrement {
-		newLen = int(r.count.Increment())
-	}
+	r.write(elems)
+	r.count.Add(int64(len(elems)))
+	r.pushed(len(elems))
 
-	return newLen, nil
+	return len(elems), nil
 }
 
 func (r *RuneRingQueue) Pop() (rune, int, error) {
 	var res rune = 0
+	if r.closed {
+		return res, 0, ErrClosed
+	}
+
 	if r.count.Value() == 0 {
 		return res, 0, ErrEmptyQueue
 	}
 
+	r.canUnread = false
 	res = r.data[r.start]                 // copy over the first element in the queue
 	r.start = (r.start + 1) % len(r.data) // move the start of the queue
 	newLen := r.count.Decrement()
+	r.reads++
+	r.popped(1)
 
 	return res, int(newLen), nil
 }
 
+/**
+ * Pop() unless the context is already done. This queue never blocks,
+ * therefore the context is only checked once.
+ * @implement roundrobin.ContextQueue[rune]
+ */
+func (r *RuneRingQueue) PopCtx(ctx context.Context) (rune, int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, 0, err
+	}
+
+	return r.Pop()
+}
+
+/**
+ * Pops up to len(dst) runes into dst with at most two copy() calls.
+ * It returns ErrEmptyQueue only when there was nothing to pop.
+ */
+func (r *RuneRingQueue) PopN(dst []rune) (n int, err error) {
+	if r.closed {
+		return 0, ErrClosed
+	}
+
+	if len(dst) == 0 {
+		return 0, nil
+	}
+
+	if r.count.IsZero() {
+		return 0, ErrEmptyQueue
+	}
+
+	r.canUnread = false
+	n = min(len(dst), r.Size())
+	r.read(dst[:n])
+	r.start = (r.start + n) % len(r.data)
+	r.count.Add(int64(-n))
+	r.reads += uint64(n)
+	r.popped(n)
+
+	return n, nil
+}
+
+/**
+ * Same as PopN(), filling dst with the oldest runes.
+ */
+func (r *RuneRingQueue) PopInto(dst []rune) (n int, err error) {
+	return r.PopN(dst)
+}
+
 func (r *RuneRingQueue) Peek() (rune, int, error) {
 	var res rune = 0
+	if r.closed {
+		return res, 0, ErrClosed
+	}
 
 	if r.count.IsZero() {
 		return res, 0, ErrEmptyQueue
@@ -130,29 +350,191 @@ func (r *RuneRingQueue) Peek() (rune, int, error) {
 	return r.data[r.start], int(r.count.Value()), nil
 }
 
+/**
+ * Peeks the newest rune, i.e. the one a Push() just added.
+ */
+func (r *RuneRingQueue) Back() (rune, int, error) {
+	if r.closed {
+		return 0, 0, ErrClosed
+	}
+
+	if r.count.IsZero() {
+		return 0, 0, ErrEmptyQueue
+	}
+
+	return r.data[(r.end-1+len(r.data))%len(r.data)], int(r.count.Value()), nil
+}
+
+/**
+ * The rune at logical position i without popping it. Zero is the
+ * oldest rune, negative positions count from the newest (-1).
+ */
+func (r *RuneRingQueue) At(i int) (rune, error) {
+	if r.closed {
+		return 0, ErrClosed
+	}
+
+	size := r.Size()
+	if i < 0 {
+		i += size
+	}
+	if i < 0 || i >= size {
+		return 0, ErrOutOfRange
+	}
+
+	return r.data[(r.start+i)%len(r.data)], nil
+}
+
+/**
+ * A copy of the runes in FIFO order.
+ */
+func (r *RuneRingQueue) ToSlice() []rune {
+	return r.AppendTo(nil)
+}
+
+/**
+ * Appends the runes in FIFO order to dst, growing it if needed.
+ */
+func (r *RuneRingQueue) AppendTo(dst []rune) []rune {
+	n, size := len(dst), r.Size()
+	dst = slices.Grow(dst, size)[:n+size]
+	r.read(dst[n:])
+
+	return dst
+}
+
 func (r *RuneRingQueue) Size() int {
+	if r.closed {
+		return 0
+	}
+
 	return int(r.count.Value())
 }
 
 func (r *RuneRingQueue) Cap() int {
+	if r.closed {
+		return 0
+	}
+
 	return len(r.data)
 }
 
 func (r *RuneRingQueue) IsFull() bool {
+	if r.closed {
+		return false
+	}
+
 	// since Ctor(capacity) is an int, this cast will never go wrong
 	// @note unless the Ctor capacity type is changed to int64
-	return len(r.data) == int(r.count.Value())
+	return len(r.data) == int(r.count.Value())+r.held()
 }
 
 /**
  * Sets the behaviour when pushing onto a full Ring Queue.
- * It can throw an error or overwrite old data.
+ * It can throw an error, overwrite old data, drop new data, grow the
+ * capacity or let a user-defined policy decide.
  */
 func (r *RuneRingQueue) SetWhenFull(a WhenFull) IRingQueue[rune] {
 	r.whenFull = a
 	return r
 }
 
+/**
+ * Sets the callback receiving each rune a full queue discards: the
+ * oldest ones WhenFullOverwrite, including those evicted by Resize()
+ * and dropped by a PushN() larger than the capacity, and the incoming
+ * ones WhenFullDropNewest.
+ */
+func (r *RuneRingQueue) SetOnEvict(callback OnEvictCallback[rune]) IRingQueue[rune] {
+	r.onEvict = callback
+	return r
+}
+
+/**
+ * Sets the policy deciding, for each rune pushed onto a full queue,
+ * what WhenFullCustom does. Without a policy it fails with ErrFullQueue.
+ */
+func (r *RuneRingQueue) SetFullPolicy(policy FullPolicy[rune]) IRingQueue[rune] {
+	r.fullPolicy = policy
+	return r
+}
+
+/**
+ * A snapshot of the statistics, which unlike the other methods may be
+ * taken while another Go routine uses the queue.
+ * @implement roundrobin.StatsQueue
+ */
+func (r *RuneRingQueue) Stats() QueueStats {
+	return r.stats.snapshot()
+}
+
+/**
+ * Starts the statistics over, the high-water mark from the current size.
+ * @implement roundrobin.StatsQueue
+ */
+func (r *RuneRingQueue) ResetStats() {
+	r.stats.reset(r.Size())
+}
+
+/**
+ * onHigh fires once a push takes the size up to high, after which
+ * onLow fires once a pop takes it under low, and so on. A high mark
+ * of zero disables them. Reset() counts as a pop.
+ * @implement roundrobin.WatermarkQueue
+ */
+func (r *RuneRingQueue) SetWatermarks(high, low int, onHigh, onLow WatermarkCallback) error {
+	return r.levels.set(high, low, onHigh, onLow, r.Size())
+}
+
+/**
+ * Marks the current head: the runes popped from now on stay in their
+ * slots, where not even WhenFullOverwrite evicts them, so that a
+ * backtracking parser can ResetToMark(). Marks may be nested.
+ */
+func (r *RuneRingQueue) Mark() Mark {
+	r.canUnread = false
+	m := Mark{at: r.reads}
+	r.marks = append(r.marks, m.at)
+	return m
+}
+
+/**
+ * Returns the runes popped since the mark to the head, in their order,
+ * discarding the marks taken after it. The mark itself stays active,
+ * it may be reset to again until committed.
+ */
+func (r *RuneRingQueue) ResetToMark(m Mark) error {
+	if r.closed {
+		return ErrClosed
+	}
+
+	i := slices.Index(r.marks, m.at)
+	if i < 0 {
+		return ErrBadMark
+	}
+
+	if n := int(r.reads - m.at); n > 0 {
+		r.canUnread = false
+		r.start = (r.start - n + len(r.data)) % len(r.data)
+		r.count.Add(int64(n))
+		r.reads = m.at
+		r.pushed(0) // not counted as pushes again
+	}
+	r.marks = r.marks[:i+1]
+
+	return nil
+}
+
+/**
+ * Releases the mark, the runes popped since are gone for good unless
+ * an older mark still holds them.
+ */
+func (r *RuneRingQueue) Commit(m Mark) {
+	if i := slices.Index(r.marks, m.at); i >= 0 {
+		r.marks = slices.Delete(r.marks, i, i+1)
+	}
+}
+
 /**
  * Throws ErrUnsupported. Simply complies with the interface.
  * @implement roundrobin.IRingQueue[rune]
@@ -162,17 +544,448 @@ func (r *RuneRingQueue) SetPopDeadline(t time.Time) error {
 }
 
 /**
- * Does nothing, simply complies with the interface.
- * @implement roundrobin.IRingQueue[rune]
+ * Sets the callback receiving, on Close(), each rune still queued.
  */
 func (r *RuneRingQueue) SetOnClose(callback OnCloseCallback[rune]) IRingQueue[rune] {
+	r.onClose = callback
 	return r
 }
 
 /**
- * Does nothing, simply complies with the interface.
+ * Replaces the hooks installed so far, OnClose included. UnreadRune()
+ * and ResetToMark() return runes without calling OnPush again.
+ */
+func (r *RuneRingQueue) SetHooks(hooks Hooks[rune]) IRingQueue[rune] {
+	r.hooks = hooks
+	return r.SetOnClose(hooks.OnClose)
+}
+
+/**
+ * Hands the remaining runes over to the OnClose callback in FIFO
+ * order and releases the data. Any further operation on the queue
+ * throws ErrClosed, closing it again does nothing.
  * @implement io.Closer
  */
 func (r *RuneRingQueue) Close() error {
+	r.closeOnce.Do(func() {
+		r.canUnread = false
+		if r.onClose != nil {
+			for range r.Size() {
+				res := r.data[r.start]
+				r.start = (r.start + 1) % len(r.data)
+				r.onClose(res)
+			}
+		}
+		r.closed = true
+		r.count.Clear()
+		r.data = nil
+	})
 	return nil
 }
+
+/**
+ * Pops the next rune along with the size of its UTF-8 encoding.
+ * An empty queue reads as io.EOF.
+ * @implement io.RuneReader
+ */
+func (r *RuneRingQueue) ReadRune() (ch rune, size int, err error) {
+	ch, _, err = r.Pop()
+	switch err {
+	case nil:
+		r.lastRead, r.canUnread = ch, true
+		return ch, runeLen(ch), nil
+	case ErrEmptyQueue:
+		return 0, 0, io.EOF
+	default:
+		return 0, 0, err
+	}
+}
+
+/**
+ * Puts the rune ReadRune() just popped back at the front, so that it
+ * is read again. It throws ErrBadUnread if anything else was popped
+ * since, and ErrFullQueue if pushes took its slot meanwhile.
+ * @implement io.RuneScanner
+ */
+func (r *RuneRingQueue) UnreadRune() error {
+	if r.closed {
+		return ErrClosed
+	}
+
+	if !r.canUnread {
+		return ErrBadUnread
+	}
+
+	if r.held() == 0 && r.IsFull() {
+		return ErrFullQueue // otherwise the slot is still held
+	}
+
+	r.canUnread = false
+	r.start = (r.start - 1 + len(r.data)) % len(r.data)
+	r.data[r.start] = r.lastRead
+	r.count.Increment()
+	r.reads--
+	r.pushed(0) // not counted as a push again
+
+	return nil
+}
+
+/**
+ * Push() returning the size of the UTF-8 encoding of the rune.
+ */
+func (r *RuneRingQueue) WriteRune(ch rune) (size int, err error) {
+	if _, err = r.Push(ch); err != nil {
+		return 0, err
+	}
+
+	return runeLen(ch), nil
+}
+
+/**
+ * Pushes the runes of s like PushN(), returning how many bytes of s
+ * were written. Like ByteRingQueue.Write(), runes that do not fit
+ * make a short write, with io.ErrShortWrite if they were dropped.
+ * @implement io.StringWriter
+ */
+func (r *RuneRingQueue) WriteString(s string) (n int, err error) {
+	runes := []rune(s)
+	written, err := r.PushN(runes)
+	if written == len(runes) {
+		return len(s), err
+	}
+
+	if err == nil {
+		err = io.ErrShortWrite
+	}
+	for n = range s {
+		if written == 0 {
+			break
+		}
+		written--
+	}
+
+	return n, err
+}
+
+/**
+ * Pops the runes up to and including the first occurrence of delim.
+ * If delim is not queued it pops every rune and returns io.EOF, like
+ * bufio.Reader.ReadString() at the end of its input.
+ */
+func (r *RuneRingQueue) ReadString(delim rune) (string, error) {
+	if r.closed {
+		return "", ErrClosed
+	}
+
+	size := r.Size()
+	n := size
+	for i := range size {
+		if r.data[(r.start+i)%len(r.data)] == delim {
+			n = i + 1
+			break
+		}
+	}
+
+	dst := make([]rune, n)
+	r.PopN(dst)
+	if n == size && (n == 0 || dst[n-1] != delim) {
+		return string(dst), io.EOF
+	}
+
+	return string(dst), nil
+}
+
+/* ----------------------------------------------------------------
+ *				P r i v a t e	M e t h o d s
+ *-----------------------------------------------------------------*/
+
+/**
+ * Push() also reporting what a full queue discarded, be it the oldest
+ * rune (WhenFullOverwrite) or the incoming one (WhenFullDropNewest).
+ */
+func (r *RuneRingQueue) pushEvict(elem rune) (newLen int, evicted rune, didEvict bool, err error) {
+	if r.closed {
+		return 0, 0, false, ErrClosed
+	}
+
+	noIncrement := false
+
+	if r.IsFull() {
+		switch r.policyFor(elem) {
+		case WhenFullError, WhenFullBlock:
+			// a plain queue cannot block, the thread-safe
+			// wrapper waits on ErrFullQueue when blocking.
+			r.stats.rejected(1)
+			return r.Size(), 0, false, ErrFullQueue
+
+		case WhenFullDropNewest:
+			// the OLDEST data is prioritized, the
+			// incoming rune is dropped silently.
+			r.evictAll([]rune{elem})
+			return r.Size(), elem, true, nil
+
+		case WhenFullOverwrite:
+			if r.held() > 0 {
+				// the runes held by a mark are spared,
+				// the oldest one still queued goes instead.
+				if r.count.IsZero() {
+					r.stats.rejected(1)
+					return 0, 0, false, ErrFullQueue
+				}
+				evicted, didEvict = r.data[r.start], true
+				r.evictHeld(1)
+				break
+			}
+
+			// continue pushing with loss of data
+			// the OLDEST data gets overwritten as
+			// fresher data is prioritized.
+			noIncrement = true
+			newLen = len(r.data)
+			evicted, didEvict = r.data[r.end], true
+			r.evict(r.end, 1)
+
+		case WhenFullGrow:
+			if !r.grow(1) {
+				r.stats.rejected(1)
+				return r.Size(), 0, false, ErrFullQueue
+			}
+
+		default:
+			return len(r.data), 0, false, errors.ErrUnsupported
+		}
+	}
+
+	r.data[r.end] = elem              // place the new element on the available space
+	r.end = (r.end + 1) % len(r.data) // move the end forward by modulo of capacity
+	if noIncrement {
+		r.start = r.end // the oldest was overwritten, the next one is now first
+	} else {
+		newLen = int(r.count.Increment())
+	}
+	r.pushed(1)
+
+	return newLen, evicted, didEvict, nil
+}
+
+/**
+ * The WhenFull applying to a rune pushed onto a full queue, which
+ * with WhenFullCustom is up to the user-defined policy.
+ */
+func (r *RuneRingQueue) policyFor(elem rune) WhenFull {
+	if r.whenFull != WhenFullCustom {
+		return r.whenFull
+	}
+
+	if r.fullPolicy == nil {
+		return WhenFullError
+	}
+
+	switch r.fullPolicy(r, elem) {
+	case DecisionDrop:
+		return WhenFullDropNewest
+	case DecisionOverwrite:
+		return WhenFullOverwrite
+	default:
+		return WhenFullError
+	}
+}
+
+/**
+ * Reallocates the data with newCap slots, evicting the oldest runes
+ * if WhenFullOverwrite and they do not fit, though never those held
+ * by a mark. The runes are moved to the beginning of the new data.
+ */
+func (r *RuneRingQueue) resize(newCap int) error {
+	r.canUnread = false
+	size, held := r.Size(), r.held()
+	if newCap < size+held {
+		if r.whenFull != WhenFullOverwrite || newCap < held {
+			return ErrFullQueue
+		}
+
+		r.evictHeld(size + held - newCap)
+		size = newCap - held
+	}
+
+	data := make([]rune, newCap)
+	r.start = (r.start - held + len(r.data)) % max(len(r.data), 1)
+	r.read(data[:held+size])
+	r.data = data
+	r.start = held % newCap
+	r.end = (held + size) % newCap
+
+	return nil
+}
+
+/**
+ * The runes popped since the oldest uncommitted mark, which remain
+ * in the slots right before the start.
+ */
+func (r *RuneRingQueue) held() int {
+	if len(r.marks) == 0 {
+		return 0
+	}
+
+	return int(r.reads - r.marks[0])
+}
+
+/**
+ * Moves the runes held by a mark n slots forward (or backward if
+ * negative), onto slots that must be free.
+ */
+func (r *RuneRingQueue) moveHeld(n int) {
+	held := r.held()
+	if held == 0 || n == 0 {
+		return
+	}
+
+	base, size := r.start-held+len(r.data), len(r.data)
+	for i := range held {
+		if n > 0 {
+			i = held - 1 - i // from the newest, not to overwrite the next
+		}
+		r.data[(base+i+n+size)%size] = r.data[(base+i)%size]
+	}
+}
+
+/**
+ * Evicts the n oldest runes still queued, moving those held by a
+ * mark forward so that they stay right before the start.
+ */
+func (r *RuneRingQueue) evictHeld(n int) {
+	r.evict(r.start, n)
+	r.moveHeld(n)
+	r.start = (r.start + n) % len(r.data)
+	r.count.Add(int64(-n))
+}
+
+/**
+ * Writes what fits and then Push()es the rest one by one, leaving
+ * the decision for each of them to the WhenFull behavior.
+ */
+func (r *RuneRingQueue) pushEach(elems []rune, free int) (written int, err error) {
+	r.write(elems[:free])
+	r.count.Add(int64(free))
+	r.pushed(free)
+	for i, elem := range elems[free:] {
+		if _, err = r.Push(elem); err != nil {
+			return free + i, err
+		}
+	}
+
+	return len(elems), nil
+}
+
+/**
+ * Accounts for the n runes just pushed, right before the end.
+ */
+func (r *RuneRingQueue) pushed(n int) {
+	r.stats.pushed(n, r.Size())
+	for i := range n {
+		r.hooks.push(r.data[(r.end-n+i+len(r.data))%len(r.data)])
+	}
+	r.levels.rose(r.Size())
+}
+
+/**
+ * Accounts for the n runes just popped, right before the start,
+ * whose slots still hold them.
+ */
+func (r *RuneRingQueue) popped(n int) {
+	r.stats.popped(n)
+	for i := range n {
+		r.hooks.pop(r.data[(r.start-n+i+len(r.data))%len(r.data)])
+	}
+	r.levels.fell(r.Size())
+}
+
+/**
+ * Hands n runes from position pos onward over to the eviction
+ * callback, before they get overwritten.
+ */
+func (r *RuneRingQueue) evict(pos, n int) {
+	r.stats.overwritten(n)
+	for i := range n {
+		evicted := r.data[(pos+i)%len(r.data)]
+		r.hooks.overwrite(evicted)
+		if r.onEvict != nil {
+			r.onEvict(evicted)
+		}
+	}
+}
+
+/**
+ * Hands incoming runes that never made it into the queue over to
+ * the eviction callback.
+ */
+func (r *RuneRingQueue) evictAll(elems []rune) {
+	r.stats.dropped(len(elems))
+	if r.onEvict == nil {
+		return
+	}
+
+	for _, elem := range elems {
+		r.onEvict(elem)
+	}
+}
+
+/**
+ * Grows the capacity by the growth factor, or more if that does not
+ * make room for need runes, without exceeding the maximum.
+ * It returns false if the capacity could not grow at all.
+ */
+func (r *RuneRingQueue) grow(need int) bool {
+	newCap := int(math.Ceil(float64(len(r.data)) * r.growFactor))
+	newCap = max(newCap, len(r.data)+need)
+	if r.maxCap > 0 {
+		newCap = min(newCap, r.maxCap)
+	}
+
+	if newCap <= len(r.data) {
+		return false
+	}
+
+	r.resize(newCap)
+	return true
+}
+
+/**
+ * Copies runes (no more than the capacity) after the last one,
+ * wrapping around at most once, and moves the end forward.
+ * The caller accounts for the new size.
+ */
+func (r *RuneRingQueue) write(elems []rune) {
+	n := copy(r.data[r.end:], elems)
+	copy(r.data, elems[n:])
+	r.end = (r.end + len(elems)) % len(r.data)
+}
+
+/**
+ * Copies the first len(dst) runes (no more than the size) into dst,
+ * wrapping around at most once. Neither start nor size are modified.
+ */
+func (r *RuneRingQueue) read(dst []rune) {
+	if len(dst) == 0 {
+		return // data may be nil once closed
+	}
+
+	n := copy(dst, r.data[r.start:])
+	copy(dst[n:], r.data)
+}
+
+/* ----------------------------------------------------------------
+ *					F u n c t i o n s
+ *-----------------------------------------------------------------*/
+
+/**
+ * The size of the UTF-8 encoding of ch, invalid runes being encoded
+ * as utf8.RuneError.
+ */
+func runeLen(ch rune) int {
+	if n := utf8.RuneLen(ch); n > 0 {
+		return n
+	}
+
+	return utf8.RuneLen(utf8.RuneError)
+}
@@ -0,0 +1,255 @@
+/* -----------------------------------------------------------------
+ *				   P u b l i c   D o m a i n / F O S
+ *				Copyright (C)2025 Dídimo Grimaldo T.
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * Tests for methods on the rune-specific RuneRingQueue
+ *-----------------------------------------------------------------*/
+package roundrobin
+
+import (
+	"io"
+	"regexp"
+	"testing"
+)
+
+/* ----------------------------------------------------------------
+ *						T e s t s
+ *-----------------------------------------------------------------*/
+
+func Test_RunePushNPopN(t *testing.T) {
+	obj := NewRuneRingQueue(4)
+	obj.PushN([]rune("ab"))
+	obj.PopN(make([]rune, 1))
+
+	written, err := obj.PushN([]rune("cdef"))
+	if written != 3 || err != ErrFullQueue {
+		t.Fatalf("expected 3 & ErrFullQueue, got %d & %v", written, err)
+	}
+
+	obj.SetWhenFull(WhenFullOverwrite)
+	if written, err = obj.PushN([]rune("ẞxyz€")); written != 5 || err != nil {
+		t.Fatalf("expected 5 & nil, got %d & %v", written, err)
+	}
+
+	dst := make([]rune, 8)
+	n, err := obj.PopN(dst)
+	if err != nil || string(dst[:n]) != "xyz€" {
+		t.Fatalf("PopN mismatch, expected: xyz€, found:%q (%v)", string(dst[:n]), err)
+	}
+	assertSize(obj, 0, t)
+}
+
+func Test_RuneRandomAccess(t *testing.T) {
+	obj := NewRuneRingQueue(3)
+	obj.PushN([]rune("abc"))
+	obj.Pop()
+	obj.Push('d')
+
+	if r, err := obj.At(-1); r != 'd' || err != nil {
+		t.Errorf("At(-1) exp 'd' got %q, %v", r, err)
+	}
+	if _, err := obj.At(3); err != ErrOutOfRange {
+		t.Errorf("At(3) should return ErrOutOfRange, got %v", err)
+	}
+	if r, _, _ := obj.Back(); r != 'd' {
+		t.Errorf("Back() exp 'd' got %q", r)
+	}
+	if s := string(obj.AppendTo([]rune(">"))); s != ">bcd" {
+		t.Errorf("AppendTo() exp \">bcd\" got %q", s)
+	}
+}
+
+func Test_RuneWhenFullGrow(t *testing.T) {
+	obj := NewRuneRingQueue(2)
+	obj.SetWhenFull(WhenFullGrow)
+	obj.PushN([]rune("ab"))
+	obj.Pop()
+
+	if _, err := obj.PushN([]rune("cde")); err != nil {
+		t.Fatalf("unexpected PushN error: %v", err)
+	}
+	if obj.Cap() != 4 || string(obj.ToSlice()) != "bcde" {
+		t.Fatalf("growth mismatch: %v", obj)
+	}
+
+	obj.Pop()
+	obj.Pop()
+	if err := obj.Shrink(); err != nil || obj.Cap() != 2 || string(obj.ToSlice()) != "de" {
+		t.Errorf("Shrink() mismatch: %v, %v", obj, err)
+	}
+}
+
+func Test_RuneOnEvict(t *testing.T) {
+	var evicted []rune
+	obj := NewRuneRingQueue(3)
+	obj.SetWhenFull(WhenFullOverwrite)
+	obj.SetOnEvict(func(r rune) {
+		evicted = append(evicted, r)
+	})
+
+	obj.PushN([]rune("abc"))
+	if r, didEvict, _ := obj.PushEvict('d'); r != 'a' || !didEvict {
+		t.Errorf("PushEvict exp 'a', true got %q, %t", r, didEvict)
+	}
+	if s := string(obj.ToSlice()); s != "bcd" {
+		t.Errorf("queue mismatch, expected: bcd, found:%q", s)
+	}
+	obj.PushN([]rune("efgh"))
+	if string(evicted) != "abcde" {
+		t.Errorf("evicted mismatch, expected: abcde, found:%q", string(evicted))
+	}
+}
+
+func Test_RuneWhenFullCustom(t *testing.T) {
+	obj := NewRuneRingQueue(3)
+	obj.SetWhenFull(WhenFullCustom)
+	obj.SetFullPolicy(func(_ IRingQueue[rune], incoming rune) Decision {
+		if incoming == ' ' {
+			return DecisionDrop
+		}
+		return DecisionFail
+	})
+
+	if written, err := obj.PushN([]rune("ab  ")); written != 4 || err != nil {
+		t.Errorf("PushN exp 4, nil got %d, %v", written, err)
+	}
+	if _, err := obj.Push('c'); err != ErrFullQueue {
+		t.Errorf("Push should return ErrFullQueue, got %v", err)
+	}
+
+	obj.SetWhenFull(WhenFullDropNewest)
+	if r, didEvict, err := obj.PushEvict('d'); r != 'd' || !didEvict || err != nil {
+		t.Errorf("PushEvict exp 'd', true, nil got %q, %t, %v", r, didEvict, err)
+	}
+	if s := string(obj.ToSlice()); s != "ab " {
+		t.Errorf("queue mismatch, expected: \"ab \", found:%q", s)
+	}
+}
+
+func Test_RuneOnClose(t *testing.T) {
+	for _, tt := range onCloseTests {
+		t.Run(tt.name, func(t *testing.T) {
+			var flushed []rune
+			rr := NewRuneRingQueue(10)
+			rr.SetWhenFull(WhenFullError)
+			rr.SetOnClose(func(data rune) {
+				flushed = append(flushed, data)
+			})
+
+			for i := 0; i < tt.pushCount; i++ {
+				rr.Push('a' + rune(i))
+			}
+			for i := 0; i < tt.popCount; i++ {
+				rr.Pop()
+			}
+			want := rr.ToSlice()
+			if err := rr.Close(); (err != nil) != tt.wantErrInClose {
+				t.Errorf("Close() error = %v, wantErr %v", err, tt.wantErrInClose)
+			}
+			if len(flushed) != tt.onCloseCount {
+				t.Errorf("onCloseCount = %v, wanted onCloseCount %v", len(flushed), tt.onCloseCount)
+			}
+			if string(flushed) != string(want) {
+				t.Errorf("expected %q flushed in FIFO order, got %q", string(want), string(flushed))
+			}
+
+			if _, err := rr.Push('z'); err != ErrClosed {
+				t.Errorf("expected ErrClosed on Push() after Close(), got %v", err)
+			}
+			if _, _, err := rr.Pop(); err != ErrClosed {
+				t.Errorf("expected ErrClosed on Pop() after Close(), got %v", err)
+			}
+			if rr.Size() != 0 || rr.Cap() != 0 {
+				t.Errorf("expected a closed queue to be empty, got %s", rr)
+			}
+			rr.Close() // no second flush
+			if len(flushed) != tt.onCloseCount {
+				t.Errorf("expected a single flush, got %d runes", len(flushed))
+			}
+		})
+	}
+}
+
+func Test_RuneScanner(t *testing.T) {
+	obj := NewRuneRingQueue(8)
+	obj.PushN([]rune("xy"))
+	obj.PopN(make([]rune, 2)) // not starting at the beginning of the data
+
+	if n, err := obj.WriteString("ẞa€"); n != 7 || err != nil {
+		t.Fatalf("expected 7 bytes & nil, got %d & %v", n, err)
+	}
+	if size, err := obj.WriteRune('b'); size != 1 || err != nil {
+		t.Fatalf("expected 1 byte & nil, got %d & %v", size, err)
+	}
+
+	if ch, size, err := obj.ReadRune(); ch != 'ẞ' || size != 3 || err != nil {
+		t.Fatalf("expected 'ẞ', 3 & nil, got %q, %d & %v", ch, size, err)
+	}
+	if err := obj.UnreadRune(); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if err := obj.UnreadRune(); err != ErrBadUnread {
+		t.Fatalf("expected ErrBadUnread on a second UnreadRune(), got %v", err)
+	}
+	if s, err := obj.ReadString('€'); s != "ẞa€" || err != nil {
+		t.Fatalf("expected \"ẞa€\" & nil, got %q & %v", s, err)
+	}
+	if err := obj.UnreadRune(); err != ErrBadUnread {
+		t.Fatalf("expected ErrBadUnread after ReadString(), got %v", err)
+	}
+
+	obj.ReadRune()
+	obj.WriteString("12345678")
+	if err := obj.UnreadRune(); err != ErrFullQueue {
+		t.Fatalf("expected ErrFullQueue once the slot is taken, got %v", err)
+	}
+	if n, err := obj.WriteString("9ẞ"); n != 0 || err != ErrFullQueue {
+		t.Fatalf("expected a short write with ErrFullQueue, got %d & %v", n, err)
+	}
+	obj.SetWhenFull(WhenFullDropNewest)
+	obj.ReadRune()
+	if n, err := obj.WriteString("9ẞ"); n != 1 || err != io.ErrShortWrite {
+		t.Fatalf("expected 1 byte & io.ErrShortWrite, got %d & %v", n, err)
+	}
+
+	if s, err := obj.ReadString(';'); s != "23456789" || err != io.EOF {
+		t.Fatalf("expected \"23456789\" & io.EOF, got %q & %v", s, err)
+	}
+	if _, _, err := obj.ReadRune(); err != io.EOF {
+		t.Fatalf("expected io.EOF when empty, got %v", err)
+	}
+
+	obj.WriteString("tokenize: ab12")
+	if !regexp.MustCompile(`^tok`).MatchReader(obj) {
+		t.Fatal("expected the queue to be usable as an io.RuneReader")
+	}
+}
+
+func Test_RuneMark(t *testing.T) {
+	obj := NewRuneRingQueue(8)
+	obj.SetWhenFull(WhenFullOverwrite)
+	obj.WriteString("let x")
+
+	m := obj.Mark()
+	obj.ReadString(' ')
+	obj.ReadRune()
+	if err := obj.UnreadRune(); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	obj.WriteString("=12345") // evicts "x=1", not the marked "let "
+	if err := obj.ResetToMark(m); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if s := string(obj.ToSlice()); s != "let 2345" {
+		t.Fatalf("expected \"let 2345\", got %q", s)
+	}
+
+	obj.ReadString(' ')
+	obj.Commit(m)
+	if err := obj.ResetToMark(m); err != ErrBadMark {
+		t.Fatalf("expected ErrBadMark after Commit(), got %v", err)
+	}
+	if s := string(obj.ToSlice()); s != "2345" {
+		t.Fatalf("expected \"2345\", got %q", s)
+	}
+}
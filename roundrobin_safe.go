@@ -10,8 +10,7 @@ package roundrobin
 
 import (
 	"context"
-	"sync"
-	"time"
+	"iter"
 
 	"github.com/pion/transport/v3/deadline"
 )
@@ -20,48 +19,53 @@ import (
  *				I n t e r f a c e s
  *-----------------------------------------------------------------*/
 
-var _ IRingQueue[int] = (*safeRQ[int])(nil)
+var _ IRingQueue[int] = (*SafeRingQueue[int])(nil)
+var _ ContextQueue[int] = (*SafeRingQueue[int])(nil)
+var _ IRingDeque[int] = (*SafeRingQueue[int])(nil)
 
 /* ----------------------------------------------------------------
- *				P r i v a t e	T y p e s
+ *				P u b l i c		T y p e s
  *-----------------------------------------------------------------*/
 
-type safeRQ[T any] struct {
-	rq    *RingQueue[T]
-	mutex sync.Mutex
-
-	closed    chan struct{}
-	closeOnce sync.Once
-
-	deadline *deadline.Deadline
-
-	whenEmpty WhenEmpty
-	available chan struct{}
+// A RingQueue made thread-safe by a mutex, which can also block on
+// a full or empty queue. Blocked Go routines are served in FIFO order.
+type SafeRingQueue[T any] struct {
+	SyncRingQueue[T]
+	rq *RingQueue[T] // the wrapped queue
 }
 
 /* ----------------------------------------------------------------
  *				C o n s t r u c t o r s
  *-----------------------------------------------------------------*/
 
-func NewSafeRingQueue[T any](capacity int, whenFull WhenFull, whenEmpty WhenEmpty, onCloseFunc OnCloseCallback[T]) *safeRQ[T] {
-	rq := NewRingQueue[T](capacity)
-	rq.SetWhenFull(whenFull).SetOnClose(onCloseFunc)
-	rq.SetOnClose(onCloseFunc)
-
+// Returns nil on an invalid WhenFull or WhenEmpty, New() reports
+// the reason with an *OptionError instead.
+func NewSafeRingQueue[T any](capacity int, whenFull WhenFull, whenEmpty WhenEmpty, onCloseFunc OnCloseCallback[T]) *SafeRingQueue[T] {
 	if whenEmpty != WhenEmptyBlock && whenEmpty != WhenEmptyError {
 		return nil
 	}
 
-	if whenFull != WhenFullOverwrite && whenFull != WhenFullError {
+	if whenFull < WhenFullError || whenFull > WhenFullCustom {
 		return nil
 	}
 
-	return &safeRQ[T]{
-		rq:        rq,
-		available: make(chan struct{}, 1),
-		deadline:  deadline.New(),
-		closed:    make(chan struct{}),
-		whenEmpty: whenEmpty,
+	rq := NewRingQueue[T](capacity)
+	rq.SetWhenFull(whenFull).SetOnClose(onCloseFunc)
+
+	return newSafeRingQueue(rq, whenFull, whenEmpty)
+}
+
+// wraps an already configured RingQueue
+func newSafeRingQueue[T any](rq *RingQueue[T], whenFull WhenFull, whenEmpty WhenEmpty) *SafeRingQueue[T] {
+	return &SafeRingQueue[T]{
+		SyncRingQueue: SyncRingQueue[T]{
+			q:            rq,
+			deadline:     deadline.New(),
+			pushDeadline: deadline.New(),
+			whenEmpty:    whenEmpty,
+			whenFull:     whenFull,
+		},
+		rq: rq,
 	}
 }
 
@@ -69,149 +73,295 @@ func NewSafeRingQueue[T any](capacity int, whenFull WhenFull, whenEmpty WhenEmpt
  *				P u b l i c		M e t h o d s
  *-----------------------------------------------------------------*/
 
-func (s *safeRQ[T]) SetPopDeadline(t time.Time) error {
-	if s.whenEmpty != WhenEmptyBlock {
-		return ErrBadDeadline
-	}
+func (s *SafeRingQueue[T]) SetOnClose(callback OnCloseCallback[T]) IRingQueue[T] {
+	s.SyncRingQueue.SetOnClose(callback)
+	return s
+}
 
-	s.deadline.Set(t)
+// The hooks run with the queue locked, they must not use the queue.
+func (s *SafeRingQueue[T]) SetHooks(hooks Hooks[T]) IRingQueue[T] {
+	s.SyncRingQueue.SetHooks(hooks)
+	return s
+}
 
-	return nil
+// The callback runs with the queue locked, it must not use the queue.
+func (s *SafeRingQueue[T]) SetOnEvict(callback OnEvictCallback[T]) IRingQueue[T] {
+	s.mutex.Lock()
+	defer s.unlock()
+
+	s.rq.SetOnEvict(callback)
+	return s
+}
+
+// The policy runs with the queue locked and receives the queue being
+// pushed onto, which it may inspect but must not modify.
+func (s *SafeRingQueue[T]) SetFullPolicy(policy FullPolicy[T]) IRingQueue[T] {
+	s.mutex.Lock()
+	defer s.unlock()
+
+	s.rq.SetFullPolicy(policy)
+	return s
 }
 
-func (s *safeRQ[T]) SetOnClose(callback OnCloseCallback[T]) IRingQueue[T] {
-	s.rq.SetOnClose(callback)
+func (s *SafeRingQueue[T]) SetWhenFull(a WhenFull) IRingQueue[T] {
+	s.SyncRingQueue.SetWhenFull(a)
 	return s
 }
 
-func (s *safeRQ[T]) SetWhenFull(a WhenFull) IRingQueue[T] {
-	s.rq.SetWhenFull(a)
+func (s *SafeRingQueue[T]) SetWhenEmpty(a WhenEmpty) IRingQueue[T] {
+	s.SyncRingQueue.SetWhenEmpty(a)
 	return s
 }
 
-func (s *safeRQ[T]) Reset() {
+func (s *SafeRingQueue[T]) SetGrowth(factor float64, maxCap int) error {
 	s.mutex.Lock()
-	defer s.mutex.Unlock()
+	defer s.unlock()
 
-	s.rq.Reset()
-	s.resetChannel(s.available)
-	s.available = make(chan struct{}, 1)
+	return s.rq.SetGrowth(factor, maxCap)
 }
 
-// @implement io.Closer
-func (s *safeRQ[T]) Close() error {
+// Like RingQueue.Resize(), growing hands the new free slots over
+// to the producers blocked by WhenFullBlock.
+func (s *SafeRingQueue[T]) Resize(newCap int) error {
 	s.mutex.Lock()
-	defer s.mutex.Unlock()
+	defer s.unlock()
 
-	s.closeOnce.Do(func() {
-		close(s.closed)
-	})
+	if err := s.rq.Resize(newCap); err != nil {
+		return err
+	}
 
-	return s.rq.Close()
+	s.signal()
+	return nil
 }
 
-// @implement fmt.Stringer
-func (s *safeRQ[T]) String() string {
+func (s *SafeRingQueue[T]) Shrink() error {
 	s.mutex.Lock()
-	defer s.mutex.Unlock()
+	defer s.unlock()
 
-	return s.rq.String()
+	return s.rq.Shrink()
 }
 
-func (s *safeRQ[T]) Size() int {
+// Like Push(), also reporting the element the full queue discarded.
+// With WhenFullBlock nothing is discarded and it may block instead.
+func (s *SafeRingQueue[T]) PushEvict(element T) (evicted T, didEvict bool, err error) {
 	s.mutex.Lock()
-	defer s.mutex.Unlock()
+	if s.whenFull == WhenFullBlock {
+		s.mutex.Unlock()
+		_, err = s.Push(element)
+		return
+	}
+	defer s.unlock()
 
-	return s.rq.Size()
+	evicted, didEvict, err = s.rq.PushEvict(element)
+	if err == nil {
+		s.signal()
+	}
+
+	return
 }
 
-func (s *safeRQ[T]) Cap() int {
+// Like RingQueue.ResetFunc(), fn runs with the queue locked and must
+// not use the queue. The producers blocked by WhenFullBlock resume.
+func (s *SafeRingQueue[T]) ResetFunc(fn func(T)) {
 	s.mutex.Lock()
-	defer s.mutex.Unlock()
+	defer s.unlock()
 
-	return s.rq.Cap()
+	s.rq.ResetFunc(fn)
+	s.signal()
 }
 
-func (s *safeRQ[T]) Push(element T) (newLen int, err error) {
-	newLen, err = s.guardedPush(element)
+// Like RingQueue.DrainTo(), pushing onto dst with the queue locked,
+// hence dst must not block waiting for this queue.
+func (s *SafeRingQueue[T]) DrainTo(dst IRingQueue[T]) (n int, err error) {
+	if dst == IRingQueue[T](s) {
+		return 0, ErrSelfDrain
+	}
 
-	if s.whenEmpty == WhenEmptyBlock {
-		select {
-		case <-s.closed:
-			return 0, ErrClosed
-		case s.available <- struct{}{}:
-			return
-		default:
-		}
+	s.mutex.Lock()
+	defer s.unlock()
+
+	n, err = s.rq.DrainTo(dst)
+	if n > 0 {
+		s.signal()
 	}
 
 	return
 }
 
-func (s *safeRQ[T]) Pop() (elem T, newLen int, err error) {
-	elem, newLen, err = s.guardedPop()
-	if err == nil {
-		return
+func (s *SafeRingQueue[T]) PushFront(element T) (newLen int, err error) {
+	return s.push(context.Background(), element, true)
+}
+
+func (s *SafeRingQueue[T]) PopBack() (elem T, newLen int, err error) {
+	return s.pop(context.Background(), true)
+}
+
+// Pushes a batch taking the lock once. With WhenFullBlock it waits
+// for free slots until the whole batch is written, otherwise it
+// behaves like RingQueue.PushN().
+func (s *SafeRingQueue[T]) PushN(elems []T) (written int, err error) {
+	s.mutex.Lock()
+	defer s.unlock()
+
+	for {
+		// blocking, it only writes what fits and waits for the rest,
+		// which is therefore not counted as rejected.
+		batch := elems[written:]
+		if s.whenFull == WhenFullBlock {
+			batch = batch[:min(len(batch), s.free())]
+		}
+
+		n, err := s.rq.PushN(batch)
+		written += n
+		if n > 0 {
+			s.signal()
+		}
+
+		if s.whenFull != WhenFullBlock || written == len(elems) || (err != nil && err != ErrFullQueue) {
+			return written, err
+		}
+
+		if s.free() > 0 {
+			continue // parked consumers were just served
+		}
+
+		// hand the next element over to the producers' line
+		w := &waiter[T]{elem: elems[written]}
+		if err := s.wait(context.Background(), &s.producers, w, s.pushDeadline); err != nil {
+			return written, err
+		}
+		if w.served {
+			if w.err != nil {
+				return written, w.err
+			}
+			written++
+		}
 	}
+}
 
-	// we have an empty queue
-	var empty T
-	switch s.whenEmpty {
-	case WhenEmptyError:
-		return empty, 0, ErrEmptyQueue
-	case WhenEmptyBlock:
-		select {
-		case <-s.closed:
-			return empty, 0, ErrClosed
-		case <-s.available:
-			return s.Pop()
-		case <-s.deadline.Done():
-			return empty, 0, context.DeadlineExceeded
+// Pops up to len(dst) elements taking the lock once. With
+// WhenEmptyBlock it waits until at least one element is available,
+// otherwise it behaves like RingQueue.PopN().
+func (s *SafeRingQueue[T]) PopN(dst []T) (n int, err error) {
+	s.mutex.Lock()
+	defer s.unlock()
+
+	for {
+		n, err = s.rq.PopN(dst)
+		if n > 0 {
+			s.signal()
+			return
+		}
+
+		if err != ErrEmptyQueue || s.whenEmpty != WhenEmptyBlock {
+			return
+		}
+
+		w := &waiter[T]{}
+		if err := s.waitPop(context.Background(), w); err != nil {
+			return 0, err
+		}
+		if w.served {
+			if w.err != nil {
+				return 0, w.err
+			}
+			dst[0] = w.elem
+			n, _ = s.rq.PopN(dst[1:])
+			s.signal()
+			return n + 1, nil
 		}
-	default:
-		panic("unreachable")
 	}
 }
 
-func (s *safeRQ[T]) Peek() (elem T, len int, err error) {
+// Same as PopN(), filling dst with the oldest elements.
+func (s *SafeRingQueue[T]) PopInto(dst []T) (n int, err error) {
+	return s.PopN(dst)
+}
+
+func (s *SafeRingQueue[T]) Back() (elem T, len int, err error) {
 	s.mutex.Lock()
-	defer s.mutex.Unlock()
+	defer s.unlock()
 
-	return s.rq.Peek()
+	return s.rq.Back()
 }
 
-/* ----------------------------------------------------------------
- *				P r i v a t e	M e t h o d s
- *-----------------------------------------------------------------*/
+func (s *SafeRingQueue[T]) PeekBack() (elem T, len int, err error) {
+	return s.Back()
+}
 
-func (s *safeRQ[T]) guardedPush(element T) (newLen int, err error) {
+func (s *SafeRingQueue[T]) At(i int) (T, error) {
 	s.mutex.Lock()
-	defer s.mutex.Unlock()
+	defer s.unlock()
 
-	newLen, err = s.rq.Push(element)
+	return s.rq.At(i)
+}
 
-	return
+// Like RingQueue.NewCursor(), the cursor takes the lock of the queue
+// and may therefore be used from another Go routine.
+func (s *SafeRingQueue[T]) NewCursor() *Cursor[T] {
+	s.mutex.Lock()
+	defer s.unlock()
+
+	return newCursor(s.rq, &s.mutex)
+}
+
+// A consistent snapshot of the elements in FIFO order.
+func (s *SafeRingQueue[T]) ToSlice() []T {
+	return s.AppendTo(nil)
 }
 
-func (s *safeRQ[T]) guardedPop() (elem T, newLen int, err error) {
+func (s *SafeRingQueue[T]) AppendTo(dst []T) []T {
 	s.mutex.Lock()
-	defer s.mutex.Unlock()
+	defer s.unlock()
 
-	elem, newLen, err = s.rq.Pop()
+	return s.rq.AppendTo(dst)
+}
 
-	return
+// Iterates over (logical index, element) pairs in FIFO order over
+// a snapshot taken when the iteration starts, therefore the queue
+// may be used concurrently (even inside the loop).
+func (s *SafeRingQueue[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, v := range s.ToSlice() {
+			if !yield(i, v) {
+				return
+			}
+		}
+	}
 }
 
-func (s *safeRQ[T]) resetChannel(ch chan struct{}) {
-	close(ch)
-	// Drain the channel non-blockingly but only attempt
-	// to read if channel is not closed (which we have)
-	for {
-		_, ok := <-ch
-		if !ok {
-			//fmt.Println("Channel closed.")
-			break // Exit the loop if the channel is closed
+// Iterates over a snapshot of the elements in FIFO order.
+func (s *SafeRingQueue[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range s.ToSlice() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Like All() but from the newest to the oldest element.
+func (s *SafeRingQueue[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		snap := s.ToSlice()
+		for i := len(snap) - 1; i >= 0; i-- {
+			if !yield(i, snap[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Pops the elements as it iterates, stopping when the queue is empty.
+// It never blocks, not even with WhenEmptyBlock.
+func (s *SafeRingQueue[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			v, err := s.tryPop()
+			if err != nil || !yield(v) {
+				return
+			}
 		}
-		//fmt.Println(value) // Process the received value
 	}
 }
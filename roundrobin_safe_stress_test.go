@@ -0,0 +1,259 @@
+/* -----------------------------------------------------------------
+ *				   P u b l i c   D o m a i n / F O S
+ *				Copyright (C)2025 Dídimo Grimaldo T.
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * Multi-producer & multi-consumer stress tests of the thread-safe
+ * queues. Run them with: go test -race -run Stress
+ *-----------------------------------------------------------------*/
+package roundrobin
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+/* ----------------------------------------------------------------
+ *						T e s t s
+ *-----------------------------------------------------------------*/
+
+// Many producers and consumers hammering a small queue which blocks
+// on both ends. Every element must be popped exactly once and no Go
+// routine may stall waiting for a wakeup that got lost.
+func Test_Stress_BlockingBothEnds(t *testing.T) {
+	stressBlockingBothEnds(t, NewSafeRingQueue[int](4, WhenFullBlock, WhenEmptyBlock, nil))
+}
+
+func Test_Stress_MPMC_BlockingBothEnds(t *testing.T) {
+	stressBlockingBothEnds(t, NewMPMCRingQueue[int](4, WhenFullBlock, WhenEmptyBlock, nil))
+}
+
+// Several consumers parked on an empty queue, then exactly as many
+// elements pushed in one burst: each of them must be woken.
+func Test_Stress_NoLostWakeups(t *testing.T) {
+	stressNoLostWakeups(t, func(capacity int) IRingQueue[int] {
+		return NewSafeRingQueue[int](capacity, WhenFullError, WhenEmptyBlock, nil)
+	})
+}
+
+func Test_Stress_MPMC_NoLostWakeups(t *testing.T) {
+	stressNoLostWakeups(t, func(capacity int) IRingQueue[int] {
+		return NewMPMCRingQueue[int](capacity, WhenFullError, WhenEmptyBlock, nil)
+	})
+}
+
+// Consumers are served in the order they started waiting
+func Test_Stress_WaitersFIFO(t *testing.T) {
+	const CONSUMERS int = 10
+	obj := NewSafeRingQueue[int](CONSUMERS, WhenFullError, WhenEmptyBlock, nil)
+
+	results := make([]chan int, CONSUMERS)
+	for i := range CONSUMERS {
+		results[i] = make(chan int, 1)
+		go func() {
+			v, _, _ := obj.Pop()
+			results[i] <- v
+		}()
+		waitForWaiters(t, &obj.SyncRingQueue, &obj.consumers, i+1)
+	}
+
+	// a late comer must not overtake the parked consumers
+	for i := range CONSUMERS {
+		obj.Push(i)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if v, _, err := obj.PopCtx(ctx); err == nil {
+		t.Errorf("late Pop overtook the parked consumers, got %d", v)
+	}
+
+	for i := range CONSUMERS {
+		select {
+		case v := <-results[i]:
+			if v != i {
+				t.Errorf("waiter #%d got %d", i, v)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("waiter #%d remained blocked", i)
+		}
+	}
+}
+
+// Close must wake every parked consumer and producer with ErrClosed
+func Test_Stress_CloseWakesAll(t *testing.T) {
+	const WAITERS int = 16
+	empty := NewSafeRingQueue[int](1, WhenFullBlock, WhenEmptyBlock, nil)
+	full := NewSafeRingQueue[int](1, WhenFullBlock, WhenEmptyBlock, nil)
+	full.Push(0)
+
+	var wg sync.WaitGroup
+	for range WAITERS {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, _, err := empty.Pop(); err != ErrClosed {
+				t.Errorf("expected ErrClosed on Pop, got %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := full.Push(1); err != ErrClosed {
+				t.Errorf("expected ErrClosed on Push, got %v", err)
+			}
+		}()
+	}
+
+	waitForWaiters(t, &empty.SyncRingQueue, &empty.consumers, WAITERS)
+	waitForWaiters(t, &full.SyncRingQueue, &full.producers, WAITERS)
+	empty.Close()
+	full.Close()
+
+	waitOrFail(t, &wg, 5*time.Second)
+}
+
+// Consumers giving up at random while being served must neither
+// lose the element handed over to them nor leave one behind.
+func Test_Stress_CancelledWaiters(t *testing.T) {
+	stressCancelledWaiters(t, NewSafeRingQueue[int](16, WhenFullBlock, WhenEmptyBlock, nil))
+}
+
+func Test_Stress_MPMC_CancelledWaiters(t *testing.T) {
+	stressCancelledWaiters(t, NewMPMCRingQueue[int](16, WhenFullBlock, WhenEmptyBlock, nil))
+}
+
+/* ----------------------------------------------------------------
+ *					F u n c t i o n s
+ *-----------------------------------------------------------------*/
+
+func waitOrFail(t *testing.T, wg *sync.WaitGroup, timeout time.Duration) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		t.Fatalf("Go routines still blocked after %v", timeout)
+	}
+}
+
+func waitForWaiters[T any](t *testing.T, obj *SyncRingQueue[T], q *waitQueue[T], n int) {
+	t.Helper()
+	for range 1000 {
+		obj.mutex.Lock()
+		parked := q.Len()
+		obj.mutex.Unlock()
+		if parked >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("expected %d parked Go routines", n)
+}
+
+func stressBlockingBothEnds(t *testing.T, obj IRingQueue[int]) {
+	t.Helper()
+	const PRODUCERS, CONSUMERS, PER_PRODUCER int = 8, 8, 2_000
+	const TOTAL int = PRODUCERS * PER_PRODUCER
+
+	seen := make([]atomic.Int32, TOTAL)
+	var popped atomic.Int64
+	var wg sync.WaitGroup
+
+	for p := range PRODUCERS {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range PER_PRODUCER {
+				if _, err := obj.Push(p*PER_PRODUCER + i); err != nil {
+					t.Errorf("unexpected Push error: %v", err)
+					return
+				}
+			}
+		}()
+	}
+
+	for range CONSUMERS {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for popped.Add(1) <= int64(TOTAL) {
+				v, _, err := obj.Pop()
+				if err != nil {
+					t.Errorf("unexpected Pop error: %v", err)
+					return
+				}
+				seen[v].Add(1)
+			}
+		}()
+	}
+
+	waitOrFail(t, &wg, 20*time.Second)
+
+	for v := range seen {
+		if n := seen[v].Load(); n != 1 {
+			t.Fatalf("value %d popped %d times", v, n)
+		}
+	}
+	assertSize(obj, 0, t)
+}
+
+func stressNoLostWakeups(t *testing.T, newQueue func(capacity int) IRingQueue[int]) {
+	t.Helper()
+	const CONSUMERS int = 32
+	for round := range 50 {
+		obj := newQueue(CONSUMERS)
+
+		var wg sync.WaitGroup
+		for range CONSUMERS {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, _, err := obj.Pop(); err != nil {
+					t.Errorf("round %d: unexpected Pop error: %v", round, err)
+				}
+			}()
+		}
+
+		for i := range CONSUMERS {
+			obj.Push(i)
+		}
+
+		waitOrFail(t, &wg, 5*time.Second)
+	}
+}
+
+func stressCancelledWaiters(t *testing.T, obj ContextQueue[int]) {
+	t.Helper()
+	const CONSUMERS, TOTAL int = 8, 5_000
+
+	var popped atomic.Int64
+	var wg sync.WaitGroup
+	for c := range CONSUMERS {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for popped.Load() < int64(TOTAL) {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Duration(c+1)*time.Microsecond)
+				if _, _, err := obj.PopCtx(ctx); err == nil {
+					popped.Add(1)
+				}
+				cancel()
+			}
+		}()
+	}
+
+	for i := range TOTAL {
+		obj.Push(i)
+	}
+
+	waitOrFail(t, &wg, 20*time.Second)
+	if n := popped.Load(); n != int64(TOTAL) {
+		t.Errorf("popped %d elements, expected %d", n, TOTAL)
+	}
+}
@@ -59,66 +59,61 @@ func Test_Deadline2(t *testing.T) {
 	}
 }
 
+type onCloseTestCase struct {
+	name           string
+	pushCount      int
+	popCount       int
+	onCloseCount   int
+	wantErrInClose bool
+}
+
+var onCloseTests = []onCloseTestCase{
+	{
+		name:           "under-push",
+		pushCount:      1,
+		popCount:       1,
+		onCloseCount:   0,
+		wantErrInClose: false,
+	},
+	{
+		name:           "over-push",
+		pushCount:      15,
+		popCount:       10,
+		onCloseCount:   0,
+		wantErrInClose: false,
+	},
+	{
+		name:           "over-push and under-pop",
+		pushCount:      15,
+		popCount:       5,
+		onCloseCount:   5,
+		wantErrInClose: false,
+	},
+	{
+		name:           "under-push and under-pop",
+		pushCount:      7,
+		popCount:       5,
+		onCloseCount:   2,
+		wantErrInClose: false,
+	},
+	{
+		name:           "over-push and over-pop",
+		pushCount:      15,
+		popCount:       15,
+		onCloseCount:   0,
+		wantErrInClose: false,
+	},
+	{
+		name:           "full",
+		pushCount:      10,
+		popCount:       0,
+		onCloseCount:   10,
+		wantErrInClose: false,
+	},
+}
+
 func Test_OnClose(t *testing.T) {
-	type testCase struct {
-		name           string
-		pushCount      int
-		popCount       int
-		onCloseCount   int
-		wantErrInClose bool
-		wantMismatch   bool
-	}
-	tests := []testCase{
-		{
-			name:           "under-push",
-			pushCount:      1,
-			popCount:       1,
-			onCloseCount:   0,
-			wantErrInClose: false,
-			wantMismatch:   false,
-		},
-		{
-			name:           "over-push",
-			pushCount:      15,
-			popCount:       10,
-			onCloseCount:   0,
-			wantErrInClose: false,
-			wantMismatch:   false,
-		},
-		{
-			name:           "over-push and under-pop",
-			pushCount:      15,
-			popCount:       5,
-			onCloseCount:   5,
-			wantErrInClose: false,
-			wantMismatch:   false,
-		},
-		{
-			name:           "under-push and under-pop",
-			pushCount:      7,
-			popCount:       5,
-			onCloseCount:   2,
-			wantErrInClose: false,
-			wantMismatch:   false,
-		},
-		{
-			name:           "over-push and over-pop",
-			pushCount:      15,
-			popCount:       15,
-			onCloseCount:   0,
-			wantErrInClose: false,
-			wantMismatch:   false,
-		},
-		{
-			name:           "mismatch",
-			pushCount:      10,
-			popCount:       0,
-			onCloseCount:   0,
-			wantErrInClose: false,
-			wantMismatch:   false,
-		},
-	}
-	for _, tt := range tests {
+	for _, tt := range onCloseTests {
 		t.Run(tt.name, func(t *testing.T) {
 			onCloseCount := 0
 			rr := NewRingQueue[int](10)
@@ -136,7 +131,7 @@ func Test_OnClose(t *testing.T) {
 			if err := rr.Close(); (err != nil) != tt.wantErrInClose {
 				t.Errorf("Close() error = %v, wantErr %v", err, tt.wantErrInClose)
 			}
-			if onCloseCount != tt.onCloseCount && !tt.wantMismatch {
+			if onCloseCount != tt.onCloseCount {
 				t.Errorf("onCloseCount = %v, wanted onCloseCount %v", onCloseCount, tt.onCloseCount)
 			}
 		})
@@ -190,13 +185,13 @@ func Test_PushFull_WhenFullOverwrite(t *testing.T) {
 		t.Errorf("pushing onto full buffer with WheFullOverwrite should NOT return an error, got: %v", err)
 	}
 
-	// since it overwrote the oldest data, we should Pop that last push...
+	// since it overwrote the oldest data, we should Pop the next oldest...
 	val, newSize, _ := obj.Pop()
 	if newSize != 4 {
 		t.Errorf("pop did not decrement: exp 4 got %d", newSize)
 	}
-	if val != OVERWRITE_DATA {
-		t.Errorf("pushing onto full buffer with WheFullError should Pop that same value. exp %d got %d", OVERWRITE_DATA, val)
+	if val != 1 {
+		t.Errorf("pushing onto full buffer with WheFullOverwrite should Pop the oldest left. exp %d got %d", 1, val)
 	}
 }
 
@@ -318,3 +313,333 @@ func Test_PopEmpty_WhenEmptyBlock2(t *testing.T) {
 
 	wg.Wait()
 }
+
+// test pushing onto a full queue configured for WhenFullBlock. The
+// Push() blocks until another Go routine pops an element, then the
+// pushed value takes the freed slot.
+func Test_PushFull_WhenFullBlock(t *testing.T) {
+	const MAX int = 3
+	const WAITING_VALUE int = 100
+	obj := NewSafeRingQueue[int](MAX, WhenFullBlock, WhenEmptyError, nil)
+	for i := range MAX {
+		obj.Push(i)
+	}
+	assertSize(obj, MAX, t)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		newLen, err := obj.Push(WAITING_VALUE)
+		if err != nil {
+			t.Errorf("blocked Push returned an error: %v", err)
+		}
+		if newLen != MAX {
+			t.Errorf("blocked Push returned wrong size. exp %d got %d", MAX, newLen)
+		}
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("expected Push on full queue to block but it completed!")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	if v, _, _ := obj.Pop(); v != 0 {
+		t.Errorf("unexpected Pop value: exp 0 got %d", v)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Push remained blocked after Pop freed a slot")
+	}
+
+	for i := range MAX {
+		exp := i + 1
+		if i == MAX-1 {
+			exp = WAITING_VALUE
+		}
+		if v, _, _ := obj.Pop(); v != exp {
+			t.Errorf("unexpected Pop value: exp %d got %d", exp, v)
+		}
+	}
+}
+
+func Test_PushDeadline(t *testing.T) {
+	obj := NewSafeRingQueue[int](1, WhenFullBlock, WhenEmptyError, nil)
+	obj.Push(1)
+
+	timeBefore := time.Now()
+	obj.SetPushDeadline(time.Now().Add(500 * time.Millisecond))
+	_, err := obj.Push(2)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected DeadlineExceeded error, got %v", err)
+	}
+	if time.Since(timeBefore) < 500*time.Millisecond {
+		t.Fatalf("Expected 500ms timeout")
+	}
+	assertSize(obj, 1, t)
+
+	obj2 := NewSafeRingQueue[int](1, WhenFullError, WhenEmptyError, nil)
+	if err := obj2.SetPushDeadline(time.Now()); err != ErrBadPushDeadline {
+		t.Errorf("Expected ErrBadPushDeadline, got %v", err)
+	}
+}
+
+func Test_PushFull_WhenFullBlock_Close(t *testing.T) {
+	obj := NewSafeRingQueue[int](1, WhenFullBlock, WhenEmptyError, nil)
+	obj.Push(1)
+
+	errCh := make(chan error)
+	go func() {
+		_, err := obj.Push(2)
+		errCh <- err
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	obj.Close()
+
+	select {
+	case err := <-errCh:
+		if err != ErrClosed {
+			t.Errorf("Expected ErrClosed, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Push remained blocked after Close")
+	}
+}
+
+// each blocked Pop() may be cancelled through its own context without
+// disturbing the other Go routines waiting on the same queue.
+func Test_PopCtx(t *testing.T) {
+	const WAITED_VALUE int = 100
+	obj := NewSafeRingQueue[int](3, WhenFullError, WhenEmptyBlock, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := make(chan error)
+	go func() {
+		_, _, err := obj.PopCtx(ctx)
+		cancelled <- err
+	}()
+
+	popped := make(chan int)
+	go func() {
+		v, _, _ := obj.PopCtx(context.Background())
+		popped <- v
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-cancelled:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("PopCtx remained blocked after cancel")
+	}
+
+	obj.Push(WAITED_VALUE)
+	select {
+	case v := <-popped:
+		if v != WAITED_VALUE {
+			t.Errorf("unexpected PopCtx value: exp %d got %d", WAITED_VALUE, v)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("PopCtx of the other waiter remained blocked after Push")
+	}
+}
+
+func Test_PushCtx(t *testing.T) {
+	obj := NewSafeRingQueue[int](1, WhenFullBlock, WhenEmptyError, nil)
+	obj.Push(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := obj.PushCtx(ctx, 2)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected DeadlineExceeded error, got %v", err)
+	}
+	assertSize(obj, 1, t)
+}
+
+// A batch larger than the free space blocks until consumers make room
+// for the rest of it, while a batch Pop returns whatever is available.
+func Test_PushN_PopN_WhenBlock(t *testing.T) {
+	obj := NewSafeRingQueue[int](4, WhenFullBlock, WhenEmptyBlock, nil)
+
+	done := make(chan int)
+	go func() {
+		written, err := obj.PushN([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+		if err != nil {
+			t.Errorf("unexpected PushN error: %v", err)
+		}
+		done <- written
+	}()
+
+	var got []int
+	dst := make([]int, 3)
+	for len(got) < 10 {
+		n, err := obj.PopN(dst)
+		if err != nil {
+			t.Fatalf("unexpected PopN error: %v", err)
+		}
+		got = append(got, dst[:n]...)
+	}
+
+	if written := <-done; written != 10 {
+		t.Errorf("expected 10 written, got %d", written)
+	}
+	if expected := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}; !eqSlices(got, expected) {
+		t.Errorf("PopN mismatch, expected:%v, found:%v", expected, got)
+	}
+}
+
+// the safe iterators work on a snapshot, hence the queue can be
+// modified inside the loop without affecting the iteration.
+func Test_SafeIterators(t *testing.T) {
+	obj := NewSafeRingQueue[int](5, WhenFullError, WhenEmptyBlock, nil)
+	obj.PushN([]int{0, 1, 2})
+
+	var got []int
+	for v := range obj.Values() {
+		obj.Push(v + 10)
+		got = append(got, v)
+	}
+	if expected := []int{0, 1, 2}; !eqSlices(got, expected) {
+		t.Errorf("Values() mismatch, expected:%v, found:%v", expected, got)
+	}
+	assertSize(obj, 5, t)
+
+	got = got[:0]
+	for v := range obj.Drain() { // must not block once empty
+		got = append(got, v)
+	}
+	if expected := []int{0, 1, 2, 10, 11}; !eqSlices(got, expected) {
+		t.Errorf("Drain() mismatch, expected:%v, found:%v", expected, got)
+	}
+	assertSize(obj, 0, t)
+
+	obj.Close()
+	for range obj.All() {
+		t.Errorf("All() yielded on a closed queue")
+	}
+}
+
+// blocked deque operations are completed at the end they asked for
+func Test_SafeDeque_WhenBlock(t *testing.T) {
+	obj := NewSafeRingQueue[int](2, WhenFullBlock, WhenEmptyBlock, nil)
+
+	popped := make(chan int)
+	go func() {
+		v, _, _ := obj.PopBack()
+		popped <- v
+	}()
+	waitForWaiters(t, &obj.SyncRingQueue, &obj.consumers, 1)
+	obj.PushN([]int{1, 2})
+	if v := <-popped; v != 2 {
+		t.Errorf("blocked PopBack exp 2 got %d", v)
+	}
+
+	obj.Push(3) // full: [1 3]
+	pushed := make(chan error)
+	go func() {
+		_, err := obj.PushFront(0)
+		pushed <- err
+	}()
+	waitForWaiters(t, &obj.SyncRingQueue, &obj.producers, 1)
+	if v, _, _ := obj.PopBack(); v != 3 {
+		t.Errorf("PopBack exp 3 got %d", v)
+	}
+	if err := <-pushed; err != nil {
+		t.Errorf("blocked PushFront returned an error: %v", err)
+	}
+	if expected := []int{0, 1}; !eqSlices(obj.ToSlice(), expected) {
+		t.Errorf("deque mismatch, expected:%v, found:%v", expected, obj.ToSlice())
+	}
+}
+
+// growing the capacity hands the new slots over to blocked producers
+func Test_Resize_WhenFullBlock(t *testing.T) {
+	obj := NewSafeRingQueue[int](1, WhenFullBlock, WhenEmptyError, nil)
+	obj.Push(0)
+
+	pushed := make(chan error)
+	go func() {
+		_, err := obj.Push(1)
+		pushed <- err
+	}()
+	waitForWaiters(t, &obj.SyncRingQueue, &obj.producers, 1)
+
+	if err := obj.Resize(2); err != nil {
+		t.Fatalf("unexpected Resize error: %v", err)
+	}
+	if err := <-pushed; err != nil {
+		t.Errorf("blocked Push returned an error: %v", err)
+	}
+	if expected := []int{0, 1}; !eqSlices(obj.ToSlice(), expected) {
+		t.Errorf("Resize mismatch, expected:%v, found:%v", expected, obj.ToSlice())
+	}
+}
+
+func Test_SafeOnEvict(t *testing.T) {
+	var evicted []int
+	obj := NewSafeRingQueue[int](2, WhenFullOverwrite, WhenEmptyError, nil)
+	obj.SetOnEvict(func(v int) {
+		evicted = append(evicted, v)
+	})
+
+	obj.PushN([]int{0, 1, 2})
+	if v, didEvict, err := obj.PushEvict(3); v != 1 || !didEvict || err != nil {
+		t.Errorf("PushEvict exp 1, true, nil got %d, %t, %v", v, didEvict, err)
+	}
+	if expected := []int{0, 1}; !eqSlices(evicted, expected) {
+		t.Errorf("evicted mismatch, expected:%v, found:%v", expected, evicted)
+	}
+}
+
+// handing the elements off frees the slots the producers wait for
+func Test_SafeDrainTo_WhenFullBlock(t *testing.T) {
+	obj := NewSafeRingQueue[int](2, WhenFullBlock, WhenEmptyError, nil)
+	obj.PushN([]int{0, 1})
+
+	pushed := make(chan error)
+	go func() {
+		_, err := obj.Push(2)
+		pushed <- err
+	}()
+	waitForWaiters(t, &obj.SyncRingQueue, &obj.producers, 1)
+
+	dst := NewRingQueue[int](4)
+	if n, err := obj.DrainTo(dst); n != 2 || err != nil {
+		t.Fatalf("DrainTo exp 2, nil got %d, %v", n, err)
+	}
+	if err := <-pushed; err != nil {
+		t.Errorf("blocked Push returned an error: %v", err)
+	}
+	if expected := []int{0, 1}; !eqSlices(dst.ToSlice(), expected) {
+		t.Errorf("DrainTo mismatch, expected:%v, found:%v", expected, dst.ToSlice())
+	}
+
+	// without deadlocking on its own lock
+	if n, err := obj.DrainTo(obj); n != 0 || err != ErrSelfDrain {
+		t.Errorf("DrainTo itself exp 0, ErrSelfDrain got %d, %v", n, err)
+	}
+
+	// a thread-safe dst tells its own drops
+	obj.Push(3)
+	dropping := NewSafeRingQueue[int](1, WhenFullDropNewest, WhenEmptyError, nil)
+	if n, err := obj.DrainTo(dropping); n != 1 || err != ErrDropped {
+		t.Errorf("DrainTo onto a dropping queue exp 1, ErrDropped got %d, %v", n, err)
+	}
+	if expected := []int{3}; !eqSlices(obj.ToSlice(), expected) {
+		t.Errorf("exp %v left got %v", expected, obj.ToSlice())
+	}
+
+	var handed []int
+	obj.ResetFunc(func(v int) { handed = append(handed, v) })
+	if expected := []int{3}; !eqSlices(handed, expected) || obj.Size() != 0 {
+		t.Errorf("ResetFunc exp %v got %v, %d left", expected, handed, obj.Size())
+	}
+}
@@ -0,0 +1,263 @@
+/* -----------------------------------------------------------------
+ *				   P u b l i c   D o m a i n / F O S
+ *				Copyright (C)2025 Dídimo Grimaldo T.
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * A lock-free ring for exactly one producer and one consumer Go
+ * routine. Each side owns one of the indices and only reads the
+ * other one, so that neither Push nor Pop takes a lock.
+ *-----------------------------------------------------------------*/
+package roundrobin
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+/* ----------------------------------------------------------------
+ *				I n t e r f a c e s
+ *-----------------------------------------------------------------*/
+
+var _ IRingQueue[int] = (*SPSCRingQueue[int])(nil)
+
+/* ----------------------------------------------------------------
+ *						G l o b a l s
+ *-----------------------------------------------------------------*/
+
+// keeps the indices of each side on its own cache line, avoiding
+// false sharing between the producer and the consumer.
+const cacheLineSize = 64
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		T y p e s
+ *-----------------------------------------------------------------*/
+
+/**
+ * A single-producer/single-consumer ring. Push() may only be called
+ * by one Go routine and Pop()/Peek() by another (or the same) one.
+ * The indices grow forever and are masked onto a power-of-two
+ * capacity, hence their difference is always the size.
+ */
+type SPSCRingQueue[T any] struct {
+	_ [cacheLineSize]byte
+
+	head       atomic.Uint64 // next to Pop(), only written by the consumer
+	cachedTail uint64        // the consumer's last view of the tail
+
+	_ [cacheLineSize - 16]byte
+
+	tail       atomic.Uint64 // next to Push(), only written by the producer
+	cachedHead uint64        // the producer's last view of the head
+
+	_ [cacheLineSize - 16]byte
+
+	data []T
+	mask uint64
+
+	whenFull  WhenFull
+	closed    atomic.Bool
+	onClose   OnCloseCallback[T]
+	closeOnce sync.Once
+	hooks     Hooks[T]
+}
+
+/* ----------------------------------------------------------------
+ *				C o n s t r u c t o r s
+ *-----------------------------------------------------------------*/
+
+/**
+ * The capacity is rounded up to the next power of two.
+ */
+func NewSPSCRingQueue[T any](capacity int) *SPSCRingQueue[T] {
+	capacity = 1 << bits.Len(uint(max(capacity, 1)-1))
+
+	return &SPSCRingQueue[T]{
+		data:     make([]T, capacity),
+		mask:     uint64(capacity - 1),
+		whenFull: WhenFullError,
+	}
+}
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		M e t h o d s
+ *-----------------------------------------------------------------*/
+
+/**
+ * Only WhenFullError (and WhenFullBlock, which like in RingQueue
+ * returns ErrFullQueue) are supported: overwriting would make the
+ * producer move the head, which belongs to the consumer.
+ */
+func (q *SPSCRingQueue[T]) SetWhenFull(a WhenFull) IRingQueue[T] {
+	q.whenFull = a
+	return q
+}
+
+func (q *SPSCRingQueue[T]) SetOnClose(callback OnCloseCallback[T]) IRingQueue[T] {
+	q.onClose = callback
+	return q
+}
+
+/**
+ * Must be set before the queue is shared. OnPush runs in the producer
+ * and OnPop in the consumer. It never overwrites, hence OnOverwrite
+ * is never called.
+ */
+func (q *SPSCRingQueue[T]) SetHooks(hooks Hooks[T]) IRingQueue[T] {
+	q.hooks = hooks
+	return q.SetOnClose(hooks.OnClose)
+}
+
+/**
+ * Not thread-safe, neither side may be in use.
+ */
+func (q *SPSCRingQueue[T]) Reset() {
+	discarded := q.Size()
+	q.head.Store(0)
+	q.tail.Store(0)
+	q.cachedHead = 0
+	q.cachedTail = 0
+	clear(q.data)
+	q.hooks.reset(discarded)
+}
+
+// @implements fmt.Stringer
+func (q *SPSCRingQueue[T]) String() string {
+	return fmt.Sprintf(
+		"[SPSC full:%t max:%d head:%d tail:%d]",
+		q.Size() == len(q.data),
+		len(q.data),
+		q.head.Load(),
+		q.tail.Load())
+}
+
+/**
+ * Producer side only. The new length may be overestimated, since
+ * the consumer might have popped meanwhile.
+ */
+func (q *SPSCRingQueue[T]) Push(elem T) (int, error) {
+	if q.closed.Load() {
+		return 0, ErrClosed
+	}
+
+	tail := q.tail.Load()
+	if tail-q.cachedHead == uint64(len(q.data)) {
+		// looks full, see whether the consumer moved on since
+		q.cachedHead = q.head.Load()
+		if tail-q.cachedHead == uint64(len(q.data)) {
+			switch q.whenFull {
+			case WhenFullError, WhenFullBlock:
+				return len(q.data), ErrFullQueue
+			default:
+				return len(q.data), errors.ErrUnsupported
+			}
+		}
+	}
+
+	q.data[tail&q.mask] = elem
+	q.tail.Store(tail + 1) // publishes the element to the consumer
+	q.hooks.push(elem)
+
+	return int(tail + 1 - q.cachedHead), nil
+}
+
+/**
+ * Push(), this queue never drops an element.
+ * @implement roundrobin.dropReporter[T]
+ */
+func (q *SPSCRingQueue[T]) pushDrop(elem T) (dropped bool, err error) {
+	_, err = q.Push(elem)
+	return false, err
+}
+
+/**
+ * Consumer side only. The new length may be underestimated, since
+ * the producer might have pushed meanwhile.
+ */
+func (q *SPSCRingQueue[T]) Pop() (T, int, error) {
+	var res T // "zero" element (respective of the type)
+	if q.closed.Load() {
+		return res, 0, ErrClosed
+	}
+
+	head := q.head.Load()
+	if head == q.cachedTail {
+		// looks empty, see whether the producer moved on since
+		q.cachedTail = q.tail.Load()
+		if head == q.cachedTail {
+			return res, 0, ErrEmptyQueue
+		}
+	}
+
+	slot := &q.data[head&q.mask]
+	res, *slot = *slot, res // do not retain a reference
+	q.head.Store(head + 1)  // hands the slot back to the producer
+	q.hooks.pop(res)
+
+	return res, int(q.cachedTail - head - 1), nil
+}
+
+/**
+ * Consumer side only.
+ */
+func (q *SPSCRingQueue[T]) Peek() (T, int, error) {
+	var res T // "zero" element (respective of the type)
+	if q.closed.Load() {
+		return res, 0, ErrClosed
+	}
+
+	head := q.head.Load()
+	q.cachedTail = q.tail.Load()
+	if head == q.cachedTail {
+		return res, 0, ErrEmptyQueue
+	}
+
+	return q.data[head&q.mask], int(q.cachedTail - head), nil
+}
+
+/**
+ * A snapshot, which either side may take.
+ */
+func (q *SPSCRingQueue[T]) Size() int {
+	if q.closed.Load() {
+		return 0
+	}
+
+	head := q.head.Load() // the tail loaded later is never behind it
+	return int(q.tail.Load() - head)
+}
+
+func (q *SPSCRingQueue[T]) Cap() int {
+	if q.closed.Load() {
+		return 0
+	}
+
+	return len(q.data)
+}
+
+/**
+ * Throws ErrUnsupported, this queue never blocks.
+ * @implement roundrobin.IRingQueue[T]
+ */
+func (q *SPSCRingQueue[T]) SetPopDeadline(t time.Time) error {
+	return errors.ErrUnsupported
+}
+
+/**
+ * Consumer side only (or once the producer is done): the remaining
+ * elements are handed over to the OnClose callback in FIFO order.
+ * @implement io.Closer
+ */
+func (q *SPSCRingQueue[T]) Close() error {
+	q.closeOnce.Do(func() {
+		q.closed.Store(true)
+		if q.onClose != nil {
+			for head, tail := q.head.Load(), q.tail.Load(); head != tail; head++ {
+				q.onClose(q.data[head&q.mask])
+			}
+		}
+	})
+	return nil
+}
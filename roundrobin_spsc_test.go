@@ -0,0 +1,86 @@
+/* -----------------------------------------------------------------
+ *				   P u b l i c   D o m a i n / F O S
+ *				Copyright (C)2025 Dídimo Grimaldo T.
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * Tests for the lock-free single-producer/single-consumer ring.
+ * Run them with: go test -race -run SPSC
+ *-----------------------------------------------------------------*/
+package roundrobin
+
+import (
+	"runtime"
+	"testing"
+)
+
+/* ----------------------------------------------------------------
+ *						T e s t s
+ *-----------------------------------------------------------------*/
+
+func Test_SPSCPushPop(t *testing.T) {
+	obj := NewSPSCRingQueue[int](3)
+	if obj.Cap() != 4 {
+		t.Fatalf("capacity should be rounded up to 4, got %d", obj.Cap())
+	}
+
+	for i := range 4 {
+		if newLen, err := obj.Push(i); newLen != i+1 || err != nil {
+			t.Fatalf("Push(%d) exp %d, nil got %d, %v", i, i+1, newLen, err)
+		}
+	}
+	if _, err := obj.Push(4); err != ErrFullQueue {
+		t.Errorf("Push on full should return ErrFullQueue, got %v", err)
+	}
+
+	for i := range 6 { // wraps around
+		v, _, err := obj.Pop()
+		if v != i || err != nil {
+			t.Fatalf("Pop() exp %d, nil got %d, %v", i, v, err)
+		}
+		obj.Push(i + 4)
+	}
+	assertSize(obj, 4, t)
+	if v, size, _ := obj.Peek(); v != 6 || size != 4 {
+		t.Errorf("Peek() exp 6, 4 got %d, %d", v, size)
+	}
+
+	var closed []int
+	obj.SetOnClose(func(v int) {
+		closed = append(closed, v)
+	})
+	obj.Close()
+	if expected := []int{6, 7, 8, 9}; !eqSlices(closed, expected) {
+		t.Errorf("onClose mismatch, expected:%v, found:%v", expected, closed)
+	}
+	if _, _, err := obj.Pop(); err != ErrClosed {
+		t.Errorf("Pop on closed should return ErrClosed, got %v", err)
+	}
+}
+
+// one producer and one consumer Go routine, every element must arrive
+// exactly once and in order.
+func Test_SPSCConcurrent(t *testing.T) {
+	const TOTAL int = 100_000
+	obj := NewSPSCRingQueue[int](64)
+
+	go func() {
+		for i := 0; i < TOTAL; {
+			if _, err := obj.Push(i); err == ErrFullQueue {
+				runtime.Gosched()
+				continue
+			}
+			i++
+		}
+	}()
+
+	for exp := 0; exp < TOTAL; {
+		v, _, err := obj.Pop()
+		if err == ErrEmptyQueue {
+			runtime.Gosched()
+			continue
+		}
+		if v != exp {
+			t.Fatalf("unexpected Pop value: exp %d got %d", exp, v)
+		}
+		exp++
+	}
+}
@@ -15,6 +15,8 @@ package roundrobin
 */
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"testing"
 )
@@ -144,6 +146,401 @@ func Test_PushPopValues(t *testing.T) {
 	}
 }
 
+func Test_ContextDone(t *testing.T) {
+	obj := NewRingQueue[int](3)
+	obj.Push(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := obj.PushCtx(ctx, 2); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled on PushCtx, got %v", err)
+	}
+	if _, _, err := obj.PopCtx(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled on PopCtx, got %v", err)
+	}
+	assertSize(obj, 1, t)
+
+	if v, _, err := obj.PopCtx(context.Background()); err != nil || v != 1 {
+		t.Errorf("unexpected PopCtx result: %d, %v", v, err)
+	}
+}
+
+func Test_PushN(t *testing.T) {
+	obj := NewRingQueue[int](5)
+	obj.Push(-1)
+	obj.Push(-2)
+	obj.Pop()
+	obj.Pop() // start & end are now at index 2
+
+	written, err := obj.PushN([]int{0, 1, 2, 3, 4, 5, 6})
+	if written != 5 || err != ErrFullQueue {
+		t.Fatalf("expected 5 & ErrFullQueue, got %d & %v", written, err)
+	}
+	assertSize(obj, 5, t)
+
+	expected := []int{3, 4, 0, 1, 2}
+	if !eqSlices(obj.data, expected) {
+		t.Fatalf("Container data mismatch, expected:%v, found:%v", expected, obj.data)
+	}
+
+	obj.SetWhenFull(WhenFullOverwrite)
+	if written, err = obj.PushN([]int{10, 11, 12, 13, 14, 15, 16}); written != 7 || err != nil {
+		t.Fatalf("expected 7 & nil, got %d & %v", written, err)
+	}
+	assertSize(obj, 5, t)
+
+	for exp := 12; exp <= 16; exp++ {
+		if v, _, _ := obj.Pop(); v != exp {
+			t.Errorf("unexpected Pop value: exp %d got %d", exp, v)
+		}
+	}
+}
+
+func Test_PopN(t *testing.T) {
+	obj := NewRingQueue[int](5)
+	if n, err := obj.PopN(make([]int, 3)); n != 0 || err != ErrEmptyQueue {
+		t.Fatalf("expected 0 & ErrEmptyQueue, got %d & %v", n, err)
+	}
+
+	obj.PushN([]int{0, 1, 2, 3})
+	obj.PopN(make([]int, 3))
+	obj.PushN([]int{4, 5, 6, 7}) // wraps around
+
+	dst := make([]int, 10)
+	n, err := obj.PopN(dst)
+	if n != 5 || err != nil {
+		t.Fatalf("expected 5 & nil, got %d & %v", n, err)
+	}
+	if expected := []int{3, 4, 5, 6, 7}; !eqSlices(dst[:n], expected) {
+		t.Fatalf("PopN mismatch, expected:%v, found:%v", expected, dst[:n])
+	}
+	assertSize(obj, 0, t)
+
+	obj.PushN([]int{8, 9})
+	if n, err = obj.PopInto(dst); n != 2 || err != nil || !eqSlices(dst[:n], []int{8, 9}) {
+		t.Fatalf("PopInto exp [8 9] & nil, got %v & %v", dst[:n], err)
+	}
+}
+
+func Test_Iterators(t *testing.T) {
+	obj := NewRingQueue[int](4)
+	obj.PushN([]int{0, 1, 2, 3})
+	obj.PopN(make([]int, 2))
+	obj.PushN([]int{4, 5}) // data: [4 5 2 3]
+
+	var got []int
+	for i, v := range obj.All() {
+		if i != len(got) {
+			t.Errorf("All() index mismatch, expected:%d, found:%d", len(got), i)
+		}
+		got = append(got, v)
+	}
+	if expected := []int{2, 3, 4, 5}; !eqSlices(got, expected) {
+		t.Errorf("All() mismatch, expected:%v, found:%v", expected, got)
+	}
+
+	got = got[:0]
+	for v := range obj.Values() {
+		if v == 4 {
+			break
+		}
+		got = append(got, v)
+	}
+	if expected := []int{2, 3}; !eqSlices(got, expected) {
+		t.Errorf("Values() mismatch, expected:%v, found:%v", expected, got)
+	}
+
+	got = got[:0]
+	for i, v := range obj.Backward() {
+		if v != i+2 {
+			t.Errorf("Backward() index mismatch at %d: %d", i, v)
+		}
+		got = append(got, v)
+	}
+	if expected := []int{5, 4, 3, 2}; !eqSlices(got, expected) {
+		t.Errorf("Backward() mismatch, expected:%v, found:%v", expected, got)
+	}
+	assertSize(obj, 4, t)
+
+	got = got[:0]
+	for v := range obj.Drain() {
+		got = append(got, v)
+		if v == 3 {
+			break
+		}
+	}
+	if expected := []int{2, 3}; !eqSlices(got, expected) {
+		t.Errorf("Drain() mismatch, expected:%v, found:%v", expected, got)
+	}
+	assertSize(obj, 2, t)
+}
+
+func Test_RandomAccess(t *testing.T) {
+	obj := NewRingQueue[int](4)
+	if _, _, err := obj.Back(); err != ErrEmptyQueue {
+		t.Errorf("Back() on empty should return ErrEmptyQueue, got %v", err)
+	}
+
+	obj.PushN([]int{0, 1, 2, 3})
+	obj.PopN(make([]int, 3))
+	obj.PushN([]int{4, 5}) // data: [4 5 2 3]
+
+	if v, size, err := obj.Back(); v != 5 || size != 3 || err != nil {
+		t.Errorf("unexpected Back() result: %d, %d, %v", v, size, err)
+	}
+
+	tests := []struct {
+		index int
+		value int
+		err   error
+	}{
+		{0, 3, nil},
+		{2, 5, nil},
+		{-1, 5, nil},
+		{-3, 3, nil},
+		{3, 0, ErrOutOfRange},
+		{-4, 0, ErrOutOfRange},
+	}
+	for _, tt := range tests {
+		if v, err := obj.At(tt.index); v != tt.value || err != tt.err {
+			t.Errorf("At(%d) exp %d, %v got %d, %v", tt.index, tt.value, tt.err, v, err)
+		}
+	}
+
+	if expected := []int{3, 4, 5}; !eqSlices(obj.ToSlice(), expected) {
+		t.Errorf("ToSlice() mismatch, expected:%v, found:%v", expected, obj.ToSlice())
+	}
+	if expected := []int{9, 3, 4, 5}; !eqSlices(obj.AppendTo([]int{9}), expected) {
+		t.Errorf("AppendTo() mismatch, expected:%v, found:%v", expected, obj.AppendTo([]int{9}))
+	}
+}
+
+func Test_Deque(t *testing.T) {
+	var obj IRingDeque[int] = NewRingQueue[int](4)
+	obj.PushFront(1)
+	obj.PushFront(0) // wraps below index zero
+	obj.Push(2)
+	obj.Push(3)
+
+	if _, err := obj.PushFront(-1); err != ErrFullQueue {
+		t.Errorf("PushFront on full should return ErrFullQueue, got %v", err)
+	}
+	if v, _, _ := obj.PeekBack(); v != 3 {
+		t.Errorf("PeekBack() exp 3 got %d", v)
+	}
+
+	// overwriting from the front evicts the newest element
+	obj.SetWhenFull(WhenFullOverwrite)
+	if newLen, err := obj.PushFront(-1); newLen != 4 || err != nil {
+		t.Errorf("PushFront with overwrite exp 4, nil got %d, %v", newLen, err)
+	}
+	if expected := []int{-1, 0, 1, 2}; !eqSlices(obj.(*RingQueue[int]).ToSlice(), expected) {
+		t.Errorf("deque mismatch, expected:%v, found:%v", expected, obj.(*RingQueue[int]).ToSlice())
+	}
+
+	for exp := 2; exp >= -1; exp-- {
+		v, newLen, err := obj.PopBack()
+		if v != exp || newLen != exp+1 || err != nil {
+			t.Errorf("PopBack() exp %d, %d, nil got %d, %d, %v", exp, exp+1, v, newLen, err)
+		}
+	}
+	if _, _, err := obj.PopBack(); err != ErrEmptyQueue {
+		t.Errorf("PopBack on empty should return ErrEmptyQueue, got %v", err)
+	}
+}
+
+func Test_Resize(t *testing.T) {
+	obj := NewRingQueue[int](4)
+	obj.PushN([]int{0, 1, 2, 3})
+	obj.PopN(make([]int, 2))
+	obj.PushN([]int{4, 5}) // data: [4 5 2 3]
+
+	if err := obj.Resize(6); err != nil {
+		t.Fatalf("unexpected Resize error: %v", err)
+	}
+	if obj.Cap() != 6 || !eqSlices(obj.ToSlice(), []int{2, 3, 4, 5}) {
+		t.Fatalf("Resize(6) mismatch: %v", obj)
+	}
+	obj.PushN([]int{6, 7})
+
+	if err := obj.Resize(3); err != ErrFullQueue {
+		t.Errorf("shrinking below size should return ErrFullQueue, got %v", err)
+	}
+	if err := obj.Resize(0); err != ErrBadCapacity {
+		t.Errorf("Resize(0) should return ErrBadCapacity, got %v", err)
+	}
+
+	// overwriting evicts the oldest
+	obj.SetWhenFull(WhenFullOverwrite)
+	if err := obj.Resize(3); err != nil {
+		t.Fatalf("unexpected Resize error: %v", err)
+	}
+	assertSize(obj, 3, t)
+	if expected := []int{5, 6, 7}; !eqSlices(obj.ToSlice(), expected) {
+		t.Errorf("Resize(3) mismatch, expected:%v, found:%v", expected, obj.ToSlice())
+	}
+}
+
+func Test_WhenFullGrow(t *testing.T) {
+	obj := NewRingQueue[int](2)
+	obj.SetWhenFull(WhenFullGrow)
+	if err := obj.SetGrowth(1, 0); err != ErrBadGrowth {
+		t.Errorf("SetGrowth(1) should return ErrBadGrowth, got %v", err)
+	}
+	obj.SetGrowth(1.5, 6)
+
+	for i := range 6 {
+		if newLen, err := obj.Push(i); newLen != i+1 || err != nil {
+			t.Fatalf("Push(%d) exp %d, nil got %d, %v", i, i+1, newLen, err)
+		}
+	}
+	if obj.Cap() != 6 {
+		t.Errorf("expected capacity 6 after growth, got %d", obj.Cap())
+	}
+	if _, err := obj.Push(6); err != ErrFullQueue {
+		t.Errorf("Push beyond the maximum should return ErrFullQueue, got %v", err)
+	}
+
+	obj.PopN(make([]int, 5))
+	if err := obj.Shrink(); err != nil || obj.Cap() != 2 {
+		t.Errorf("Shrink() exp capacity 2 got %d, %v", obj.Cap(), err)
+	}
+	if v, _, _ := obj.Pop(); v != 5 {
+		t.Errorf("unexpected Pop value: exp 5 got %d", v)
+	}
+
+	// a batch grows once to fit
+	obj.SetGrowth(2, 0)
+	if written, err := obj.PushN([]int{0, 1, 2, 3, 4}); written != 5 || err != nil {
+		t.Errorf("PushN exp 5, nil got %d, %v", written, err)
+	}
+	if obj.Cap() != 5 {
+		t.Errorf("expected capacity 5 after PushN, got %d", obj.Cap())
+	}
+}
+
+func Test_OnEvict(t *testing.T) {
+	var evicted []int
+	obj := NewRingQueue[int](3)
+	obj.SetWhenFull(WhenFullOverwrite)
+	obj.SetOnEvict(func(v int) {
+		evicted = append(evicted, v)
+	})
+
+	if _, didEvict, err := obj.PushEvict(0); didEvict || err != nil {
+		t.Errorf("PushEvict on non-full exp false, nil got %t, %v", didEvict, err)
+	}
+	obj.PushN([]int{1, 2})
+	if v, didEvict, err := obj.PushEvict(3); v != 0 || !didEvict || err != nil {
+		t.Errorf("PushEvict on full exp 0, true, nil got %d, %t, %v", v, didEvict, err)
+	}
+
+	obj.Reset()
+	obj.PushN([]int{1, 2, 3})
+	obj.PushFront(-1)                // evicts the newest: 3
+	obj.PushN([]int{10, 11, 12, 13}) // evicts -1, 1, 2 & drops 10
+	obj.Resize(2)                    // evicts 11
+	expected := []int{0, 3, -1, 1, 2, 10, 11}
+	if !eqSlices(evicted, expected) {
+		t.Errorf("evicted mismatch, expected:%v, found:%v", expected, evicted)
+	}
+	if expected := []int{12, 13}; !eqSlices(obj.ToSlice(), expected) {
+		t.Errorf("queue mismatch, expected:%v, found:%v", expected, obj.ToSlice())
+	}
+}
+
+// Push() and PushN() overwriting the oldest leave the same FIFO order
+func Test_OverwriteOrder(t *testing.T) {
+	obj := NewRingQueue[int](3)
+	obj.SetWhenFull(WhenFullOverwrite)
+	for i := 1; i <= 5; i++ {
+		obj.Push(i)
+	}
+	if expected := []int{3, 4, 5}; !eqSlices(obj.ToSlice(), expected) {
+		t.Errorf("Push order mismatch, expected:%v, found:%v", expected, obj.ToSlice())
+	}
+	if v, _ := obj.At(0); v != 3 {
+		t.Errorf("At(0) exp 3 got %d", v)
+	}
+
+	other := NewRingQueue[int](3)
+	other.SetWhenFull(WhenFullOverwrite)
+	other.PushN([]int{1, 2, 3, 4, 5})
+	if !eqSlices(other.ToSlice(), obj.ToSlice()) {
+		t.Errorf("PushN order %v differs from Push order %v", other.ToSlice(), obj.ToSlice())
+	}
+
+	for exp := 3; exp <= 5; exp++ {
+		if v, _, _ := obj.Pop(); v != exp {
+			t.Errorf("unexpected Pop value: exp %d got %d", exp, v)
+		}
+	}
+}
+
+func Test_WhenFullDropNewest(t *testing.T) {
+	var dropped []int
+	obj := NewRingQueue[int](3)
+	obj.SetWhenFull(WhenFullDropNewest).(*RingQueue[int]).SetOnEvict(func(v int) {
+		dropped = append(dropped, v)
+	})
+	obj.PushN([]int{0, 1, 2})
+
+	if newLen, err := obj.Push(3); newLen != 3 || err != nil {
+		t.Errorf("Push on full exp 3, nil got %d, %v", newLen, err)
+	}
+	if v, didEvict, err := obj.PushEvict(4); v != 4 || !didEvict || err != nil {
+		t.Errorf("PushEvict on full exp 4, true, nil got %d, %t, %v", v, didEvict, err)
+	}
+	obj.Pop()
+	if written, err := obj.PushN([]int{5, 6}); written != 1 || err != nil {
+		t.Errorf("PushN exp 1, nil got %d, %v", written, err)
+	}
+
+	if expected := []int{3, 4, 6}; !eqSlices(dropped, expected) {
+		t.Errorf("dropped mismatch, expected:%v, found:%v", expected, dropped)
+	}
+	if expected := []int{1, 2, 5}; !eqSlices(obj.ToSlice(), expected) {
+		t.Errorf("queue mismatch, expected:%v, found:%v", expected, obj.ToSlice())
+	}
+}
+
+func Test_WhenFullCustom(t *testing.T) {
+	obj := NewRingQueue[int](2)
+	obj.SetWhenFull(WhenFullCustom)
+	obj.PushN([]int{1, 2})
+	if _, err := obj.Push(3); err != ErrFullQueue {
+		t.Errorf("WhenFullCustom without policy should return ErrFullQueue, got %v", err)
+	}
+
+	// negatives are dropped, zero fails and positives overwrite
+	obj.SetFullPolicy(func(q IRingQueue[int], incoming int) Decision {
+		if q.Size() != q.Cap() {
+			t.Errorf("policy invoked on a non-full queue")
+		}
+		switch {
+		case incoming < 0:
+			return DecisionDrop
+		case incoming == 0:
+			return DecisionFail
+		default:
+			return DecisionOverwrite
+		}
+	})
+
+	if newLen, err := obj.Push(-1); newLen != 2 || err != nil {
+		t.Errorf("dropping Push exp 2, nil got %d, %v", newLen, err)
+	}
+	if _, err := obj.Push(0); err != ErrFullQueue {
+		t.Errorf("failing Push should return ErrFullQueue, got %v", err)
+	}
+	if v, didEvict, err := obj.PushEvict(3); v != 1 || !didEvict || err != nil {
+		t.Errorf("overwriting PushEvict exp 1, true, nil got %d, %t, %v", v, didEvict, err)
+	}
+	if written, err := obj.PushN([]int{-2, 0, 4}); written != 1 || err != ErrFullQueue {
+		t.Errorf("PushN exp 1, ErrFullQueue got %d, %v", written, err)
+	}
+}
+
 /* ----------------------------------------------------------------
  *					F u n c t i o n s
  *-----------------------------------------------------------------*/
@@ -168,3 +565,237 @@ func assertSize[T any](obj IRingQueue[T], expected int, t *testing.T) {
 		t.Errorf("Incorrect size reported, expected:%d, got:%d", expected, obj.Size())
 	}
 }
+
+func Test_Mark(t *testing.T) {
+	var evicted []int
+	obj := NewRingQueue[int](4)
+	obj.SetWhenFull(WhenFullOverwrite).(*RingQueue[int]).SetOnEvict(func(v int) {
+		evicted = append(evicted, v)
+	})
+	obj.PushN([]int{0, 1, 2, 3})
+	obj.Pop()
+	obj.Push(4)
+
+	m := obj.Mark()
+	obj.Pop()
+	obj.Pop()
+	obj.PushN([]int{5, 6}) // evicts 3 & 4, not the marked 1 & 2
+	if err := obj.ResetToMark(m); err != nil {
+		t.Fatalf("ResetToMark exp nil got %v", err)
+	}
+	if expected := []int{1, 2, 5, 6}; !eqSlices(obj.ToSlice(), expected) {
+		t.Errorf("queue mismatch, expected:%v, found:%v", expected, obj.ToSlice())
+	}
+	if expected := []int{3, 4}; !eqSlices(evicted, expected) {
+		t.Errorf("evicted mismatch, expected:%v, found:%v", expected, evicted)
+	}
+
+	// nested marks
+	obj.Pop()
+	inner := obj.Mark()
+	obj.Pop()
+	obj.ResetToMark(inner)
+	if v, _, _ := obj.Peek(); v != 2 {
+		t.Errorf("inner ResetToMark exp 2 at the head got %d", v)
+	}
+	obj.ResetToMark(m)
+	if v, _, _ := obj.Peek(); v != 1 {
+		t.Errorf("outer ResetToMark exp 1 at the head got %d", v)
+	}
+	if err := obj.ResetToMark(inner); err != ErrBadMark {
+		t.Errorf("ResetToMark past the outer mark exp ErrBadMark got %v", err)
+	}
+
+	obj.Pop()
+	obj.Pop()
+	obj.Commit(m)
+	if obj.IsFull() {
+		t.Errorf("committed slots should be free, found %v", obj)
+	}
+	if err := obj.ResetToMark(m); err != ErrBadMark {
+		t.Errorf("ResetToMark after Commit exp ErrBadMark got %v", err)
+	}
+
+	// fully held, nothing may be evicted
+	obj.Reset()
+	obj.PushN([]int{1, 2, 3, 4})
+	m = obj.Mark()
+	obj.PopN(make([]int, 4))
+	if _, err := obj.Push(5); err != ErrFullQueue {
+		t.Errorf("Push with every slot held exp ErrFullQueue got %v", err)
+	}
+
+	// the marked elements survive PushFront() and Resize()
+	obj.ResetToMark(m)
+	obj.Pop()
+	obj.PushFront(0)                      // evicts the newest: 4
+	if err := obj.Resize(3); err != nil { // evicts 0
+		t.Fatalf("Resize exp nil got %v", err)
+	}
+	obj.ResetToMark(m)
+	if expected := []int{1, 2, 3}; !eqSlices(obj.ToSlice(), expected) {
+		t.Errorf("queue mismatch, expected:%v, found:%v", expected, obj.ToSlice())
+	}
+}
+
+func Test_Sequence(t *testing.T) {
+	obj := NewRingQueue[int](4)
+	if obj.NewestSeq() != 0 || obj.OldestSeq() != 1 {
+		t.Errorf("empty exp sequences 1..0 got %d..%d", obj.OldestSeq(), obj.NewestSeq())
+	}
+
+	for i := range 4 {
+		if seq, err := obj.PushSeq(i * 10); seq != uint64(i+1) || err != nil {
+			t.Errorf("PushSeq exp %d, nil got %d, %v", i+1, seq, err)
+		}
+	}
+	if seq, err := obj.PushSeq(40); seq != 0 || err != ErrFullQueue {
+		t.Errorf("PushSeq on full exp 0, ErrFullQueue got %d, %v", seq, err)
+	}
+	obj.SetWhenFull(WhenFullDropNewest)
+	if seq, err := obj.PushSeq(40); seq != 0 || err != nil {
+		t.Errorf("PushSeq dropped exp 0, nil got %d, %v", seq, err)
+	}
+
+	obj.SetWhenFull(WhenFullOverwrite)
+	obj.PushN([]int{40, 50}) // overwrites 1 & 2
+	if obj.OldestSeq() != 3 || obj.NewestSeq() != 6 {
+		t.Errorf("exp sequences 3..6 got %d..%d", obj.OldestSeq(), obj.NewestSeq())
+	}
+
+	elems, missed := obj.Since(1)
+	if expected := []int{20, 30, 40, 50}; !eqSlices(elems, expected) || missed != 1 {
+		t.Errorf("Since(1) exp %v, 1 got %v, %d", expected, elems, missed)
+	}
+	elems, missed = obj.Since(4)
+	if expected := []int{40, 50}; !eqSlices(elems, expected) || missed != 0 {
+		t.Errorf("Since(4) exp %v, 0 got %v, %d", expected, elems, missed)
+	}
+	if elems, missed = obj.Since(6); elems != nil || missed != 0 {
+		t.Errorf("Since(6) exp nothing got %v, %d", elems, missed)
+	}
+
+	// monotonic even across a Reset()
+	obj.Reset()
+	if seq, _ := obj.PushSeq(60); seq != 7 {
+		t.Errorf("PushSeq after Reset exp 7 got %d", seq)
+	}
+	if elems, missed = obj.Since(0); !eqSlices(elems, []int{60}) || missed != 6 {
+		t.Errorf("Since(0) exp [60], 6 got %v, %d", elems, missed)
+	}
+}
+
+// the numbers of the elements taken back or pushed onto the front
+// are never reused
+func Test_SequenceNotReused(t *testing.T) {
+	obj := NewRingQueue[int](4)
+	obj.PushSeq(10)
+	obj.PushSeq(20)
+	obj.PopBack() // 20 goes with its number 2
+	if seq, _ := obj.PushSeq(30); seq != 3 {
+		t.Errorf("PushSeq after PopBack exp 3 got %d", seq)
+	}
+	if obj.OldestSeq() != 1 || obj.NewestSeq() != 3 {
+		t.Errorf("exp sequences 1..3 got %d..%d", obj.OldestSeq(), obj.NewestSeq())
+	}
+	if elems, missed := obj.Since(2); !eqSlices(elems, []int{30}) || missed != 0 {
+		t.Errorf("Since(2) exp [30], 0 got %v, %d", elems, missed)
+	}
+	if elems, missed := obj.Since(1); !eqSlices(elems, []int{30}) || missed != 1 {
+		t.Errorf("Since(1) exp [30], 1 got %v, %d", elems, missed)
+	}
+
+	obj.PushFront(0) // numbered 4
+	if seq, _ := obj.PushSeq(40); seq != 5 {
+		t.Errorf("PushSeq after PushFront exp 5 got %d", seq)
+	}
+
+	obj.SetWhenFull(WhenFullOverwrite)
+	obj.PushFront(-10) // evicts 40, numbered 6
+	if seq, _ := obj.PushSeq(50); seq != 7 || obj.NewestSeq() != 7 {
+		t.Errorf("PushSeq after an overwriting PushFront exp 7 got %d", seq)
+	}
+}
+
+func Test_ResetFunc(t *testing.T) {
+	obj := NewRingQueue[int](4)
+	obj.PushN([]int{0, 1, 2, 3})
+	obj.Pop()
+	obj.Push(4) // wraps around
+
+	var handed []int
+	obj.ResetFunc(func(elem int) { handed = append(handed, elem) })
+	if expected := []int{1, 2, 3, 4}; !eqSlices(handed, expected) {
+		t.Errorf("ResetFunc exp %v got %v", expected, handed)
+	}
+	if obj.Size() != 0 {
+		t.Errorf("exp empty queue got size %d", obj.Size())
+	}
+}
+
+func Test_DrainTo(t *testing.T) {
+	obj := NewRingQueue[int](4)
+	obj.PushN([]int{0, 1, 2, 3})
+	obj.Pop()
+	obj.Push(4)
+
+	dst := NewRingQueue[int](2)
+	if n, err := obj.DrainTo(dst); n != 2 || err != ErrFullQueue {
+		t.Errorf("DrainTo onto a small queue exp 2, ErrFullQueue got %d, %v", n, err)
+	}
+	if expected := []int{3, 4}; !eqSlices(obj.ToSlice(), expected) {
+		t.Errorf("exp %v left got %v", expected, obj.ToSlice())
+	}
+
+	dst.Resize(8)
+	if n, err := obj.DrainTo(dst); n != 2 || err != nil {
+		t.Errorf("DrainTo exp 2, nil got %d, %v", n, err)
+	}
+	if expected := []int{1, 2, 3, 4}; !eqSlices(dst.ToSlice(), expected) || obj.Size() != 0 {
+		t.Errorf("exp %v moved got %v, %d left", expected, dst.ToSlice(), obj.Size())
+	}
+
+	obj.PushN([]int{5, 6, 7})
+	if n, err := obj.DrainTo(obj); n != 0 || err != ErrSelfDrain || obj.Size() != 3 {
+		t.Errorf("DrainTo itself exp 0, ErrSelfDrain & 3 left got %d, %v & %d", n, err, obj.Size())
+	}
+
+	// the element a full dst drops stays queued, along with the next
+	dropping := NewRingQueue[int](1)
+	dropping.SetWhenFull(WhenFullDropNewest)
+	if n, err := obj.DrainTo(dropping); n != 1 || err != ErrDropped {
+		t.Errorf("DrainTo onto a dropping queue exp 1, ErrDropped got %d, %v", n, err)
+	}
+	if expected := []int{6, 7}; !eqSlices(obj.ToSlice(), expected) || !eqSlices(dropping.ToSlice(), []int{5}) {
+		t.Errorf("exp %v left & [5] moved got %v & %v", expected, obj.ToSlice(), dropping.ToSlice())
+	}
+
+	// the lock-free queue reports its drops as well
+	lockFree := NewMPMCRingQueue[int](2, WhenFullDropNewest, WhenEmptyError, nil)
+	obj.PushN([]int{8, 9})
+	if n, err := obj.DrainTo(lockFree); n != 2 || err != ErrDropped {
+		t.Errorf("DrainTo onto a dropping MPMC exp 2, ErrDropped got %d, %v", n, err)
+	}
+	if expected := []int{8, 9}; !eqSlices(obj.ToSlice(), expected) {
+		t.Errorf("exp %v left got %v", expected, obj.ToSlice())
+	}
+
+	// a dst that cannot tell its drops is refused
+	runes := NewRingQueue[rune](2)
+	runes.Push('a')
+	if n, err := runes.DrainTo(NewRuneRingQueue(2)); n != 0 || !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("DrainTo onto a RuneRingQueue exp 0, ErrUnsupported got %d, %v", n, err)
+	}
+
+	// emptied without firing OnReset
+	resets := 0
+	obj.SetHooks(Hooks[int]{OnReset: func(int) { resets++ }})
+	if n, err := obj.DrainTo(NewRingQueue[int](4)); n != 2 || err != nil || resets != 0 {
+		t.Errorf("DrainTo exp 2, nil & no OnReset got %d, %v & %d", n, err, resets)
+	}
+
+	obj.Close()
+	if _, err := obj.DrainTo(dst); err != ErrClosed {
+		t.Errorf("DrainTo on closed exp ErrClosed got %v", err)
+	}
+}
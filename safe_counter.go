@@ -39,6 +39,10 @@ func (c *SafeCounter) Decrement() int64 {
 	return atomic.AddInt64(&c.counter, -1)
 }
 
+func (c *SafeCounter) Add(delta int64) int64 {
+	return atomic.AddInt64(&c.counter, delta)
+}
+
 func (c *SafeCounter) Value() int64 {
 	return atomic.LoadInt64(&c.counter)
 }
@@ -50,3 +54,14 @@ func (c *SafeCounter) IsZero() bool {
 func (c *SafeCounter) Clear() {
 	atomic.StoreInt64(&c.counter, 0)
 }
+
+// Raises the counter to v unless it is already higher, returning
+// the resulting value.
+func (c *SafeCounter) Max(v int64) int64 {
+	for {
+		old := atomic.LoadInt64(&c.counter)
+		if old >= v || atomic.CompareAndSwapInt64(&c.counter, old, v) {
+			return max(old, v)
+		}
+	}
+}
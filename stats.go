@@ -0,0 +1,89 @@
+/* -----------------------------------------------------------------
+ *				   P u b l i c   D o m a i n / F O S
+ *				Copyright (C)2025 Dídimo Grimaldo T.
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * Runtime statistics of the queues, kept in SafeCounters so that
+ * they can be read while the queue is in use without its lock.
+ *-----------------------------------------------------------------*/
+package roundrobin
+
+import (
+	"fmt"
+	"time"
+)
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		T y p e s
+ *-----------------------------------------------------------------*/
+
+// A snapshot of the activity of a queue since it was created, or
+// since its last ResetStats().
+type QueueStats struct {
+	Pushes     uint64        // elements pushed
+	Pops       uint64        // elements popped
+	Overwrites uint64        // elements evicted to make room (WhenFullOverwrite)
+	Drops      uint64        // incoming elements that never made it (WhenFullDropNewest)
+	Rejections uint64        // elements refused with ErrFullQueue
+	BlockedPop time.Duration // time spent by Pop() waiting for data
+	HighWater  int           // the largest size reached
+}
+
+/* ----------------------------------------------------------------
+ *				P r i v a t e	T y p e s
+ *-----------------------------------------------------------------*/
+
+// the counters behind QueueStats, which the queue updates as it goes.
+type statsBlock struct {
+	pushes     SafeCounter
+	pops       SafeCounter
+	overwrites SafeCounter
+	drops      SafeCounter
+	rejections SafeCounter
+	blockedPop SafeCounter // nanoseconds
+	highWater  SafeCounter
+}
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// @implements fmt.Stringer
+func (s QueueStats) String() string {
+	return fmt.Sprintf(
+		"[Stats pushes:%d pops:%d overwrites:%d drops:%d rejections:%d blockedPop:%v highWater:%d]",
+		s.Pushes,
+		s.Pops,
+		s.Overwrites,
+		s.Drops,
+		s.Rejections,
+		s.BlockedPop,
+		s.HighWater)
+}
+
+/* ----------------------------------------------------------------
+ *				P r i v a t e	M e t h o d s
+ *-----------------------------------------------------------------*/
+
+func (s *statsBlock) snapshot() QueueStats {
+	return QueueStats{
+		Pushes:     uint64(s.pushes.Value()),
+		Pops:       uint64(s.pops.Value()),
+		Overwrites: uint64(s.overwrites.Value()),
+		Drops:      uint64(s.drops.Value()),
+		Rejections: uint64(s.rejections.Value()),
+		BlockedPop: time.Duration(s.blockedPop.Value()),
+		HighWater:  int(s.highWater.Value()),
+	}
+}
+
+// the high-water mark starts over from the current size.
+func (s *statsBlock) reset(size int) {
+	s.pushes.Clear()
+	s.pops.Clear()
+	s.overwrites.Clear()
+	s.drops.Clear()
+	s.rejections.Clear()
+	s.blockedPop.Clear()
+	s.highWater.Clear()
+	s.highWater.Max(int64(size))
+}
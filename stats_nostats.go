@@ -0,0 +1,20 @@
+//go:build nostats
+
+/* -----------------------------------------------------------------
+ *				   P u b l i c   D o m a i n / F O S
+ *				Copyright (C)2025 Dídimo Grimaldo T.
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * Statistics left out by the nostats build tag, only meant to measure
+ * their overhead: go test -tags nostats -run '^$' -bench RingQueue
+ *-----------------------------------------------------------------*/
+package roundrobin
+
+/* ----------------------------------------------------------------
+ *				P r i v a t e	M e t h o d s
+ *-----------------------------------------------------------------*/
+
+func (s *statsBlock) pushed(n, size int) {}
+func (s *statsBlock) popped(n int)       {}
+func (s *statsBlock) overwritten(n int)  {}
+func (s *statsBlock) dropped(n int)      {}
+func (s *statsBlock) rejected(n int)     {}
@@ -0,0 +1,103 @@
+//go:build !nostats
+
+/* -----------------------------------------------------------------
+ *				   P u b l i c   D o m a i n / F O S
+ *				Copyright (C)2025 Dídimo Grimaldo T.
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * Tests for the runtime statistics of the queues
+ *-----------------------------------------------------------------*/
+package roundrobin
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+/* ----------------------------------------------------------------
+ *						T e s t s
+ *-----------------------------------------------------------------*/
+
+func Test_Stats(t *testing.T) {
+	obj := NewRingQueue[int](4)
+	obj.PushN([]int{0, 1, 2})
+	obj.Pop()
+	obj.PushN([]int{3, 4, 5}) // 5 is rejected
+	obj.Push(6)               // rejected
+	obj.SetWhenFull(WhenFullDropNewest)
+	obj.Push(7) // dropped
+	obj.SetWhenFull(WhenFullOverwrite)
+	obj.PushN([]int{8, 9}) // overwrite two
+	obj.PopN(make([]int, 2))
+
+	expected := QueueStats{Pushes: 7, Pops: 3, Overwrites: 2, Drops: 1, Rejections: 2, HighWater: 4}
+	if stats := obj.Stats(); stats != expected {
+		t.Errorf("stats mismatch, expected:%v, found:%v", expected, stats)
+	}
+
+	obj.ResetStats()
+	if expected := (QueueStats{HighWater: 2}); obj.Stats() != expected {
+		t.Errorf("stats mismatch, expected:%v, found:%v", expected, obj.Stats())
+	}
+
+	runes := NewRuneRingQueue(2)
+	runes.WriteString("abc")
+	runes.ReadRune()
+	if expected := (QueueStats{Pushes: 2, Pops: 1, Rejections: 1, HighWater: 2}); runes.Stats() != expected {
+		t.Errorf("stats mismatch, expected:%v, found:%v", expected, runes.Stats())
+	}
+}
+
+// read while the queue is in use, and accounting for the blocked time
+func Test_SafeStats(t *testing.T) {
+	const total = 1_000
+	obj := NewSafeRingQueue[int](8, WhenFullBlock, WhenEmptyBlock, nil)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := range total {
+			obj.Push(i)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for {
+			if stats := obj.Stats(); stats.Pops == total {
+				return
+			}
+		}
+	}()
+
+	for range total {
+		obj.Pop()
+	}
+	wg.Wait()
+
+	stats := obj.Stats()
+	if stats.Pushes != total || stats.Rejections != 0 || stats.HighWater > 8 {
+		t.Errorf("unexpected stats %v", stats)
+	}
+
+	// a blocking PushN() waits for the elements that do not fit yet
+	small := NewSafeRingQueue[int](2, WhenFullBlock, WhenEmptyBlock, nil)
+	go func() {
+		for range 4 {
+			small.Pop()
+		}
+	}()
+	if n, err := small.PushN([]int{1, 2, 3, 4}); n != 4 || err != nil {
+		t.Fatalf("blocking PushN exp 4, nil got %d, %v", n, err)
+	}
+	if stats := small.Stats(); stats.Pushes != 4 || stats.Rejections != 0 {
+		t.Errorf("blocking PushN exp 4 pushes & no rejections got %v", stats)
+	}
+
+	obj.ResetStats()
+	obj.SetPopDeadline(time.Now().Add(50 * time.Millisecond))
+	obj.Pop()
+	if blocked := obj.Stats().BlockedPop; blocked < 50*time.Millisecond {
+		t.Errorf("BlockedPop exp at least 50ms got %v", blocked)
+	}
+}
@@ -0,0 +1,39 @@
+//go:build !nostats
+
+/* -----------------------------------------------------------------
+ *				   P u b l i c   D o m a i n / F O S
+ *				Copyright (C)2025 Dídimo Grimaldo T.
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * How the queues update their statistics as they go. Building with
+ * the nostats tag leaves them out, see stats_nostats.go.
+ *-----------------------------------------------------------------*/
+package roundrobin
+
+/* ----------------------------------------------------------------
+ *				P r i v a t e	M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// n elements pushed, leaving size elements queued.
+func (s *statsBlock) pushed(n, size int) {
+	s.pushes.Add(int64(n))
+	s.highWater.Max(int64(size))
+}
+
+func (s *statsBlock) popped(n int) {
+	s.pops.Add(int64(n))
+}
+
+// n elements evicted to make room
+func (s *statsBlock) overwritten(n int) {
+	s.overwrites.Add(int64(n))
+}
+
+// n incoming elements that never made it into the queue
+func (s *statsBlock) dropped(n int) {
+	s.drops.Add(int64(n))
+}
+
+// n elements refused with ErrFullQueue
+func (s *statsBlock) rejected(n int) {
+	s.rejections.Add(int64(n))
+}
@@ -0,0 +1,492 @@
+/* -----------------------------------------------------------------
+ *				   P u b l i c   D o m a i n / F O S
+ *				Copyright (C)2025 Dídimo Grimaldo T.
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * The locking, blocking, deadline and close semantics of the
+ * thread-safe queue, around any IRingQueue implementation.
+ *-----------------------------------------------------------------*/
+package roundrobin
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/pion/transport/v3/deadline"
+)
+
+/* ----------------------------------------------------------------
+ *				I n t e r f a c e s
+ *-----------------------------------------------------------------*/
+
+var _ IRingQueue[int] = (*SyncRingQueue[int])(nil)
+var _ ContextQueue[int] = (*SyncRingQueue[int])(nil)
+var _ PushDeadlineQueue[int] = (*SyncRingQueue[int])(nil)
+var _ StatsQueue = (*SyncRingQueue[int])(nil)
+var _ WatermarkQueue = (*SyncRingQueue[int])(nil)
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		T y p e s
+ *-----------------------------------------------------------------*/
+
+// Serialises every call to the wrapped queue with a mutex. A Push()
+// onto a full queue blocks with WhenFullBlock, and a Pop() from an
+// empty one blocks with WhenEmptyBlock, serving the blocked Go
+// routines in FIFO order. The wrapped queue must not be used directly.
+type SyncRingQueue[T any] struct {
+	q      IRingQueue[T]
+	closed bool
+	mutex  sync.Mutex
+
+	deadline     *deadline.Deadline
+	pushDeadline *deadline.Deadline
+
+	whenEmpty  WhenEmpty
+	consumers  waitQueue[T] // Pop() waiting for data
+	blockedPop SafeCounter  // nanoseconds spent by them
+
+	whenFull  WhenFull
+	producers waitQueue[T] // Push() waiting for a free slot
+
+	pending   []func() // watermark callbacks due once unlocked
+	notifying bool     // a Go routine is running them
+}
+
+/* ----------------------------------------------------------------
+ *				C o n s t r u c t o r s
+ *-----------------------------------------------------------------*/
+
+// Makes any queue safe for concurrent use, initially with
+// WhenFullError and WhenEmptyError. The full behavior of the wrapped
+// queue still applies unless SetWhenFull(WhenFullBlock).
+func Synchronized[T any](q IRingQueue[T]) *SyncRingQueue[T] {
+	return &SyncRingQueue[T]{
+		q:            q,
+		deadline:     deadline.New(),
+		pushDeadline: deadline.New(),
+		whenEmpty:    WhenEmptyError,
+		whenFull:     WhenFullError,
+	}
+}
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		M e t h o d s
+ *-----------------------------------------------------------------*/
+
+func (s *SyncRingQueue[T]) SetPopDeadline(t time.Time) error {
+	if s.whenEmpty != WhenEmptyBlock {
+		return ErrBadDeadline
+	}
+
+	s.deadline.Set(t)
+
+	return nil
+}
+
+func (s *SyncRingQueue[T]) SetPushDeadline(t time.Time) error {
+	if s.whenFull != WhenFullBlock {
+		return ErrBadPushDeadline
+	}
+
+	s.pushDeadline.Set(t)
+
+	return nil
+}
+
+func (s *SyncRingQueue[T]) SetOnClose(callback OnCloseCallback[T]) IRingQueue[T] {
+	s.mutex.Lock()
+	defer s.unlock()
+
+	s.q.SetOnClose(callback)
+	return s
+}
+
+// The hooks run with the queue locked, they must not use the queue.
+func (s *SyncRingQueue[T]) SetHooks(hooks Hooks[T]) IRingQueue[T] {
+	s.mutex.Lock()
+	defer s.unlock()
+
+	s.q.SetHooks(hooks)
+	return s
+}
+
+func (s *SyncRingQueue[T]) SetWhenFull(a WhenFull) IRingQueue[T] {
+	s.mutex.Lock()
+	defer s.unlock()
+
+	s.whenFull = a
+	s.q.SetWhenFull(a)
+	if a != WhenFullBlock {
+		// blocked producers retry under the new policy
+		s.producers.wakeAll()
+	}
+	return s
+}
+
+func (s *SyncRingQueue[T]) SetWhenEmpty(a WhenEmpty) IRingQueue[T] {
+	s.mutex.Lock()
+	defer s.unlock()
+
+	s.whenEmpty = a
+	if a != WhenEmptyBlock {
+		// blocked consumers retry under the new policy
+		s.consumers.wakeAll()
+	}
+	return s
+}
+
+func (s *SyncRingQueue[T]) Reset() {
+	s.mutex.Lock()
+	defer s.unlock()
+
+	s.q.Reset()
+	s.signal()
+}
+
+// @implement io.Closer
+func (s *SyncRingQueue[T]) Close() error {
+	s.mutex.Lock()
+	defer s.unlock()
+
+	s.closed = true
+	err := s.q.Close()
+	s.consumers.wakeAll()
+	s.producers.wakeAll()
+
+	return err
+}
+
+// @implement fmt.Stringer
+func (s *SyncRingQueue[T]) String() string {
+	s.mutex.Lock()
+	defer s.unlock()
+
+	return s.q.String()
+}
+
+func (s *SyncRingQueue[T]) Size() int {
+	s.mutex.Lock()
+	defer s.unlock()
+
+	return s.q.Size()
+}
+
+func (s *SyncRingQueue[T]) Cap() int {
+	s.mutex.Lock()
+	defer s.unlock()
+
+	return s.q.Cap()
+}
+
+func (s *SyncRingQueue[T]) Push(element T) (newLen int, err error) {
+	return s.push(context.Background(), element, false)
+}
+
+// Like Push() but a WhenFullBlock wait is abandoned with ctx.Err()
+// when the context is done. Other waiters are not affected.
+func (s *SyncRingQueue[T]) PushCtx(ctx context.Context, element T) (newLen int, err error) {
+	return s.push(ctx, element, false)
+}
+
+func (s *SyncRingQueue[T]) Pop() (elem T, newLen int, err error) {
+	return s.pop(context.Background(), false)
+}
+
+// Like Pop() but a WhenEmptyBlock wait is abandoned with ctx.Err()
+// when the context is done. Other waiters are not affected.
+func (s *SyncRingQueue[T]) PopCtx(ctx context.Context) (elem T, newLen int, err error) {
+	return s.pop(ctx, false)
+}
+
+func (s *SyncRingQueue[T]) Peek() (elem T, len int, err error) {
+	s.mutex.Lock()
+	defer s.unlock()
+
+	return s.q.Peek()
+}
+
+// The Go routines blocked in Push() or Pop().
+func (s *SyncRingQueue[T]) Waiters() int {
+	s.mutex.Lock()
+	defer s.unlock()
+
+	return s.consumers.Len() + s.producers.Len()
+}
+
+// The statistics of the wrapped queue, if it keeps any, along with
+// the time Pop() spent blocked. It does not take the lock.
+// @implement roundrobin.StatsQueue
+func (s *SyncRingQueue[T]) Stats() QueueStats {
+	var stats QueueStats
+	if q, ok := s.q.(StatsQueue); ok {
+		stats = q.Stats()
+	}
+	stats.BlockedPop = time.Duration(s.blockedPop.Value())
+
+	return stats
+}
+
+// @implement roundrobin.StatsQueue
+func (s *SyncRingQueue[T]) ResetStats() {
+	s.mutex.Lock()
+	defer s.unlock()
+
+	if q, ok := s.q.(StatsQueue); ok {
+		q.ResetStats()
+	}
+	s.blockedPop.Clear()
+}
+
+// Like RingQueue.SetWatermarks() except that the callbacks run after
+// the lock is released, one at a time and in the order they fired,
+// hence they may use the queue. Requires a WatermarkQueue underneath.
+// @implement roundrobin.WatermarkQueue
+func (s *SyncRingQueue[T]) SetWatermarks(high, low int, onHigh, onLow WatermarkCallback) error {
+	s.mutex.Lock()
+	defer s.unlock()
+
+	q, ok := s.q.(WatermarkQueue)
+	if !ok {
+		return errors.ErrUnsupported
+	}
+
+	return q.SetWatermarks(high, low, s.deferred(onHigh), s.deferred(onLow))
+}
+
+/* ----------------------------------------------------------------
+ *				P r i v a t e	M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// releases the mutex, first running the pending watermark callbacks
+// without it. Callbacks due meanwhile, even by the callbacks
+// themselves, are left to the Go routine already running them.
+func (s *SyncRingQueue[T]) unlock() {
+	if s.notifying || len(s.pending) == 0 {
+		s.mutex.Unlock()
+		return
+	}
+
+	s.notifying = true
+	for len(s.pending) > 0 {
+		calls := s.pending
+		s.pending = nil
+		s.mutex.Unlock()
+		for _, call := range calls {
+			call()
+		}
+		s.mutex.Lock()
+	}
+	s.notifying = false
+	s.mutex.Unlock()
+}
+
+// a callback for the wrapped queue which merely queues the call
+// to the given one until unlock()
+func (s *SyncRingQueue[T]) deferred(callback WatermarkCallback) WatermarkCallback {
+	if callback == nil {
+		return nil
+	}
+
+	return func(size int) {
+		s.pending = append(s.pending, func() { callback(size) })
+	}
+}
+
+// a Pop() that never blocks
+func (s *SyncRingQueue[T]) tryPop() (elem T, err error) {
+	s.mutex.Lock()
+	defer s.unlock()
+
+	elem, _, err = s.q.Pop()
+	if err == nil {
+		s.signal()
+	}
+
+	return
+}
+
+// Push() telling whether the wrapped queue dropped the element, which
+// it must be able to tell. Blocking, it waits for a slot instead.
+// @implement roundrobin.dropReporter[T]
+func (s *SyncRingQueue[T]) pushDrop(elem T) (dropped bool, err error) {
+	s.mutex.Lock()
+	if s.whenFull == WhenFullBlock {
+		s.mutex.Unlock()
+		_, err = s.Push(elem)
+		return false, err
+	}
+	defer s.unlock()
+
+	q, ok := s.q.(dropReporter[T])
+	if !ok {
+		return false, errors.ErrUnsupported
+	}
+
+	if dropped, err = q.pushDrop(elem); err == nil {
+		s.signal()
+	}
+
+	return
+}
+
+func (s *SyncRingQueue[T]) free() int {
+	return s.q.Cap() - s.q.Size()
+}
+
+// Push() or, at the other end, PushFront() on the wrapped queue.
+// The latter requires an IRingDeque.
+func (s *SyncRingQueue[T]) pushAt(elem T, front bool) (int, error) {
+	if !front {
+		return s.q.Push(elem)
+	}
+
+	if d, ok := s.q.(IRingDeque[T]); ok {
+		return d.PushFront(elem)
+	}
+
+	return s.q.Size(), errors.ErrUnsupported
+}
+
+// Pop() or, at the other end, PopBack() on the wrapped queue.
+// The latter requires an IRingDeque.
+func (s *SyncRingQueue[T]) popAt(back bool) (elem T, newLen int, err error) {
+	if !back {
+		return s.q.Pop()
+	}
+
+	if d, ok := s.q.(IRingDeque[T]); ok {
+		return d.PopBack()
+	}
+
+	return elem, s.q.Size(), errors.ErrUnsupported
+}
+
+// Push() or, at the other end, PushFront()
+func (s *SyncRingQueue[T]) push(ctx context.Context, element T, front bool) (newLen int, err error) {
+	if err = ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mutex.Lock()
+	defer s.unlock()
+
+	for {
+		if s.closed {
+			return 0, ErrClosed
+		}
+
+		// producers only wait on a full queue, hence a free slot
+		// means nobody is ahead of us.
+		if s.whenFull != WhenFullBlock || s.free() > 0 {
+			newLen, err = s.pushAt(element, front)
+			if err == nil {
+				s.signal()
+			}
+			return
+		}
+
+		// we have a full queue, wait for a Pop() to free a slot
+		w := &waiter[T]{elem: element, otherEnd: front}
+		if err = s.wait(ctx, &s.producers, w, s.pushDeadline); err != nil {
+			return s.q.Size(), err
+		}
+		if w.served {
+			return w.newLen, w.err
+		}
+	}
+}
+
+// Pop() or, at the other end, PopBack()
+func (s *SyncRingQueue[T]) pop(ctx context.Context, back bool) (elem T, newLen int, err error) {
+	if err = ctx.Err(); err != nil {
+		return elem, 0, err
+	}
+
+	s.mutex.Lock()
+	defer s.unlock()
+
+	for {
+		if s.closed {
+			return elem, 0, ErrClosed
+		}
+
+		// consumers only wait on an empty queue, hence an element
+		// means nobody is ahead of us.
+		if s.q.Size() > 0 {
+			elem, newLen, err = s.popAt(back)
+			s.signal()
+			return
+		}
+
+		// we have an empty queue
+		switch s.whenEmpty {
+		case WhenEmptyError:
+			return elem, 0, ErrEmptyQueue
+		case WhenEmptyBlock:
+			w := &waiter[T]{otherEnd: back}
+			if err = s.waitPop(ctx, w); err != nil {
+				return elem, 0, err
+			}
+			if w.served {
+				return w.elem, w.newLen, w.err
+			}
+		default:
+			panic("unreachable")
+		}
+	}
+}
+
+// hands elements over to parked consumers and free slots over to
+// parked producers, in the order they started waiting. Must be
+// called with the mutex held after any change of size.
+func (s *SyncRingQueue[T]) signal() {
+	for s.consumers.Len() > 0 && s.q.Size() > 0 {
+		w := s.consumers.dequeue()
+		w.elem, w.newLen, w.err = s.popAt(w.otherEnd)
+		w.served = true
+		w.wake()
+	}
+
+	for s.producers.Len() > 0 && s.free() > 0 {
+		w := s.producers.dequeue()
+		w.newLen, w.err = s.pushAt(w.elem, w.otherEnd)
+		w.served = true
+		w.wake()
+	}
+}
+
+// wait() for data, accounting for the time blocked
+func (s *SyncRingQueue[T]) waitPop(ctx context.Context, w *waiter[T]) error {
+	since := time.Now()
+	defer func() {
+		s.blockedPop.Add(int64(time.Since(since)))
+	}()
+
+	return s.wait(ctx, &s.consumers, w, s.deadline)
+}
+
+// parks the caller at the back of the wait queue, releasing the
+// mutex until it is woken up, the deadline passes or ctx is done.
+// The mutex is held again on return. A waiter served at the very
+// moment it gave up keeps the result and reports no error.
+func (s *SyncRingQueue[T]) wait(ctx context.Context, q *waitQueue[T], w *waiter[T], dl *deadline.Deadline) error {
+	e := q.enqueue(w)
+	s.unlock()
+
+	var err error
+	select {
+	case <-w.ready:
+	case <-dl.Done():
+		err = context.DeadlineExceeded
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	s.mutex.Lock()
+	if err != nil && !q.abandon(e) {
+		err = nil
+	}
+
+	return err
+}
@@ -0,0 +1,66 @@
+/* -----------------------------------------------------------------
+ *				   P u b l i c   D o m a i n / F O S
+ *				Copyright (C)2025 Dídimo Grimaldo T.
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * Tests for the thread-safe decorator of any IRingQueue
+ *-----------------------------------------------------------------*/
+package roundrobin
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+/* ----------------------------------------------------------------
+ *						T e s t s
+ *-----------------------------------------------------------------*/
+
+// a rune queue shared between a lexer reading ahead and its feeder
+func Test_Synchronized(t *testing.T) {
+	obj := Synchronized[rune](NewRuneRingQueue(2))
+	obj.SetWhenFull(WhenFullBlock)
+	obj.SetWhenEmpty(WhenEmptyBlock)
+
+	const INPUT string = "ẞyntax"
+	go func() {
+		for _, r := range INPUT {
+			if _, err := obj.Push(r); err != nil {
+				t.Errorf("unexpected Push error: %v", err)
+			}
+		}
+	}()
+
+	var got []rune
+	for range []rune(INPUT) {
+		r, _, err := obj.Pop()
+		if err != nil {
+			t.Fatalf("unexpected Pop error: %v", err)
+		}
+		got = append(got, r)
+	}
+	if string(got) != INPUT {
+		t.Errorf("Pop mismatch, expected:%q, found:%q", INPUT, string(got))
+	}
+
+	obj.SetPopDeadline(time.Now().Add(50 * time.Millisecond))
+	if _, _, err := obj.Pop(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected DeadlineExceeded error, got %v", err)
+	}
+
+	obj.SetPopDeadline(time.Time{})
+	popped := make(chan error)
+	go func() {
+		_, _, err := obj.Pop()
+		popped <- err
+	}()
+	waitForWaiters(t, obj, &obj.consumers, 1)
+	obj.Close()
+	if err := <-popped; err != ErrClosed {
+		t.Errorf("Expected ErrClosed, got %v", err)
+	}
+	if _, err := obj.Push('x'); err != ErrClosed {
+		t.Errorf("Push on closed should return ErrClosed, got %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+/* -----------------------------------------------------------------
+ *				   P u b l i c   D o m a i n / F O S
+ *				Copyright (C)2025 Dídimo Grimaldo T.
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * A FIFO list of parked Go routines used by the thread-safe queue.
+ * Rather than waking everybody and letting them race for the lock,
+ * the operation a waiter is waiting for is completed on its behalf
+ * and only then is it woken up (direct hand-off).
+ *-----------------------------------------------------------------*/
+package roundrobin
+
+import (
+	"container/list"
+)
+
+/* ----------------------------------------------------------------
+ *				P r i v a t e	T y p e s
+ *-----------------------------------------------------------------*/
+
+// a parked Go routine, woken up by closing its channel.
+type waiter[T any] struct {
+	ready    chan struct{}
+	otherEnd bool // waiting to PushFront() or PopBack()
+	served   bool // the operation was completed on its behalf
+	elem     T    // element offered by a producer or handed to a consumer
+	newLen   int
+	err      error
+}
+
+// Not thread-safe: the owner must hold its own mutex on every call.
+type waitQueue[T any] struct {
+	waiters list.List
+}
+
+/* ----------------------------------------------------------------
+ *				P r i v a t e	M e t h o d s
+ *-----------------------------------------------------------------*/
+
+func (w *waitQueue[T]) Len() int {
+	return w.waiters.Len()
+}
+
+// park a new waiter at the back of the list
+func (w *waitQueue[T]) enqueue(wt *waiter[T]) *list.Element {
+	wt.ready = make(chan struct{})
+	return w.waiters.PushBack(wt)
+}
+
+// removes the longest waiting one, nil if there is none.
+// The caller serves it and then wakes it up.
+func (w *waitQueue[T]) dequeue() *waiter[T] {
+	e := w.waiters.Front()
+	if e == nil {
+		return nil
+	}
+
+	return w.waiters.Remove(e).(*waiter[T])
+}
+
+// wake every waiter without serving it, used when the queue is
+// closed or its policy no longer requires waiting.
+func (w *waitQueue[T]) wakeAll() {
+	for wt := w.dequeue(); wt != nil; wt = w.dequeue() {
+		wt.wake()
+	}
+}
+
+// called by a waiter that gave up (deadline or context). It returns
+// false if the waiter had already been woken up in the meantime.
+func (w *waitQueue[T]) abandon(e *list.Element) bool {
+	select {
+	case <-e.Value.(*waiter[T]).ready:
+		return false
+	default:
+		w.waiters.Remove(e)
+		return true
+	}
+}
+
+func (wt *waiter[T]) wake() {
+	close(wt.ready)
+}
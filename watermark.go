@@ -0,0 +1,68 @@
+/* -----------------------------------------------------------------
+ *				   P u b l i c   D o m a i n / F O S
+ *				Copyright (C)2025 Dídimo Grimaldo T.
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * High and low watermarks with hysteresis, so that producers can be
+ * throttled before the queue fills up and resumed once it drained.
+ *-----------------------------------------------------------------*/
+package roundrobin
+
+/* ----------------------------------------------------------------
+ *				P r i v a t e	T y p e s
+ *-----------------------------------------------------------------*/
+
+// The state behind SetWatermarks(). Between the two marks the
+// callbacks do not fire again, whichever way the size moves.
+type watermarks struct {
+	high   int
+	low    int
+	onHigh WatermarkCallback
+	onLow  WatermarkCallback
+	above  bool // reached high and did not fall under low since
+}
+
+/* ----------------------------------------------------------------
+ *				P r i v a t e	M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// replaces the marks, with size elements currently queued. A queue
+// already at its high mark is considered above it without notice.
+func (w *watermarks) set(high, low int, onHigh, onLow WatermarkCallback, size int) error {
+	if low < 0 || high < low {
+		return ErrBadWatermarks
+	}
+
+	*w = watermarks{
+		high:   high,
+		low:    low,
+		onHigh: onHigh,
+		onLow:  onLow,
+		above:  high > 0 && size >= high,
+	}
+
+	return nil
+}
+
+// fires onHigh if a push just took the size up to the high mark.
+func (w *watermarks) rose(size int) {
+	if w.above || w.high == 0 || size < w.high {
+		return
+	}
+
+	w.above = true
+	if w.onHigh != nil {
+		w.onHigh(size)
+	}
+}
+
+// fires onLow if a pop just took the size under the low mark.
+func (w *watermarks) fell(size int) {
+	if !w.above || size >= w.low {
+		return
+	}
+
+	w.above = false
+	if w.onLow != nil {
+		w.onLow(size)
+	}
+}
@@ -0,0 +1,97 @@
+/* -----------------------------------------------------------------
+ *				   P u b l i c   D o m a i n / F O S
+ *				Copyright (C)2025 Dídimo Grimaldo T.
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * Tests for the high and low watermark callbacks
+ *-----------------------------------------------------------------*/
+package roundrobin
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+)
+
+/* ----------------------------------------------------------------
+ *						T e s t s
+ *-----------------------------------------------------------------*/
+
+func Test_Watermarks(t *testing.T) {
+	var events []string
+	onHigh := func(size int) { events = append(events, fmt.Sprintf("high@%d", size)) }
+	onLow := func(size int) { events = append(events, fmt.Sprintf("low@%d", size)) }
+
+	obj := NewRingQueue[int](8)
+	if err := obj.SetWatermarks(2, 3, onHigh, onLow); err != ErrBadWatermarks {
+		t.Errorf("low above high exp ErrBadWatermarks got %v", err)
+	}
+	if err := obj.SetWatermarks(6, 2, onHigh, onLow); err != nil {
+		t.Fatalf("SetWatermarks exp nil got %v", err)
+	}
+
+	obj.PushN([]int{1, 2, 3, 4, 5})
+	obj.Pop()                          // 4, nothing fired yet
+	obj.PushN([]int{6, 7})             // 6, high
+	obj.Push(8)                        // 7, still above
+	obj.PopN(make([]int, 4))           // 3, between the marks
+	obj.Push(9)                        // 4, no high again
+	obj.PopN(make([]int, 3))           // 1, low
+	obj.Pop()                          // 0, already below
+	obj.PushN([]int{1, 2, 3, 4, 5, 6}) // 6, high
+	obj.Reset()                        // 0, low
+
+	expected := []string{"high@6", "low@1", "high@6", "low@0"}
+	if !slices.Equal(events, expected) {
+		t.Errorf("events mismatch, expected:%v, found:%v", expected, events)
+	}
+
+	events = nil
+	runes := NewRuneRingQueue(4)
+	runes.SetWatermarks(3, 1, onHigh, onLow)
+	runes.WriteString("abc")
+	runes.ReadRune()
+	runes.UnreadRune() // back to 3, which already fired
+	runes.ReadString('c')
+	expected = []string{"high@3", "low@0"}
+	if !slices.Equal(events, expected) {
+		t.Errorf("rune events mismatch, expected:%v, found:%v", expected, events)
+	}
+}
+
+// the callbacks run without the lock, hence they may use the queue
+func Test_SafeWatermarks(t *testing.T) {
+	obj := NewSafeRingQueue[int](4, WhenFullBlock, WhenEmptyBlock, nil)
+
+	var mu sync.Mutex
+	var events []string
+	obj.SetWatermarks(3, 1,
+		func(size int) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, fmt.Sprintf("high@%d/%d", size, obj.Size()))
+		},
+		func(size int) {
+			obj.Push(100) // re-enters the queue from within a callback
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, fmt.Sprintf("low@%d", size))
+		})
+
+	obj.PushN([]int{1, 2, 3})
+	obj.PopN(make([]int, 3))
+
+	mu.Lock()
+	expected := []string{"high@3/3", "low@0"}
+	if !slices.Equal(events, expected) {
+		t.Errorf("events mismatch, expected:%v, found:%v", expected, events)
+	}
+	mu.Unlock()
+	if obj.Size() != 1 {
+		t.Errorf("size exp 1 got %d", obj.Size())
+	}
+
+	if err := Synchronized[int](NewSPSCRingQueue[int](4)).SetWatermarks(3, 1, nil, nil); err == nil {
+		t.Error("SetWatermarks on a queue without watermarks exp an error")
+	}
+}